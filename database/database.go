@@ -18,8 +18,17 @@ func ConnectDB() {
 		return
 	}
 	DB = db
+	migrateDateColumns(DB)
 	DB.AutoMigrate(&models.Task{})
 	DB.AutoMigrate(&models.User{})
 	DB.AutoMigrate(&models.Holiday{})
 	DB.AutoMigrate(&models.TaskAssignment{})
+	DB.AutoMigrate(&models.WorkingCalendar{})
+	DB.AutoMigrate(&models.UserSchedule{})
+	DB.AutoMigrate(&models.HolidayRule{})
+	DB.AutoMigrate(&models.HolidayCalendar{})
+	DB.AutoMigrate(&models.Leave{})
+	DB.AutoMigrate(&models.TaskDependency{})
+	DB.AutoMigrate(&models.TimeEntry{})
+	migrateEstimateMinutes(DB)
 }
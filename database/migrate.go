@@ -0,0 +1,156 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// dateLayouts are the layouts assignment dates were stored in as text; the
+// second has no offset and is local time in the assignment's timezone
+var dateLayouts = []string{"2006-01-02 3:04 PM -07:00", "2006-01-02 3:04 PM"}
+
+// isTextColumn reports whether a column of model still has a text type
+func isTextColumn(db *gorm.DB, model interface{}, column string) bool {
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	if err != nil {
+		return false
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() == column {
+			name := strings.ToLower(columnType.DatabaseTypeName())
+			return name == "text" || strings.Contains(name, "char")
+		}
+	}
+	return false
+}
+
+// migrateDateColumns converts the text date columns of an existing database
+// to timestamptz and date before AutoMigrate runs. Assignment dates without
+// an offset are read in the assignment's or the assignee's timezone.
+func migrateDateColumns(db *gorm.DB) {
+	if db.Migrator().HasTable(&models.TaskAssignment{}) && isTextColumn(db, &models.TaskAssignment{}, "start_date") {
+		if err := migrateAssignmentDates(db); err != nil {
+			log.Fatalf("Error migrating assignment dates: %v", err)
+		}
+	}
+	if db.Migrator().HasTable(&models.Holiday{}) && isTextColumn(db, &models.Holiday{}, "holiday_date") {
+		err := db.Exec("ALTER TABLE holidays ALTER COLUMN holiday_date TYPE date USING NULLIF(holiday_date, '')::date").Error
+		if err != nil {
+			log.Fatalf("Error migrating holiday dates: %v", err)
+		}
+	}
+}
+
+func migrateAssignmentDates(db *gorm.DB) error {
+	zones := make(map[string]string)
+	if db.Migrator().HasColumn(&models.UserSchedule{}, "timezone") {
+		var schedules []struct {
+			Username string
+			Timezone string
+		}
+		db.Table("user_schedules").Select("username, timezone").Scan(&schedules)
+		for _, schedule := range schedules {
+			zones[schedule.Username] = schedule.Timezone
+		}
+	}
+	columns := "id, username, start_date, end_date"
+	if db.Migrator().HasColumn(&models.TaskAssignment{}, "timezone") {
+		columns += ", timezone"
+	}
+	var rows []struct {
+		ID        uint
+		Username  string
+		StartDate string
+		EndDate   string
+		Timezone  string
+	}
+	if err := db.Table("task_assignments").Select(columns).Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	// every row must convert; a date is never made up, so an unreadable row
+	// stops the migration with the old columns untouched
+	type converted struct {
+		id         uint
+		start, end time.Time
+	}
+	var dates []converted
+	var unreadable []string
+	for _, row := range rows {
+		zone := row.Timezone
+		if zone == "" {
+			zone = zones[row.Username]
+		}
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			loc = time.UTC
+		}
+		start, startErr := parseStoredDate(row.StartDate, loc)
+		end, endErr := parseStoredDate(row.EndDate, loc)
+		if startErr != nil || endErr != nil {
+			unreadable = append(unreadable, fmt.Sprintf("%d (%q, %q)", row.ID, row.StartDate, row.EndDate))
+			continue
+		}
+		dates = append(dates, converted{row.ID, start, end})
+	}
+	if len(unreadable) > 0 {
+		return fmt.Errorf("task assignments with unreadable dates, correct or delete them and restart: %s", strings.Join(unreadable, ", "))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			"ALTER TABLE task_assignments ADD COLUMN start_at timestamptz",
+			"ALTER TABLE task_assignments ADD COLUMN end_at timestamptz",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		for _, row := range dates {
+			if err := tx.Exec("UPDATE task_assignments SET start_at = ?, end_at = ? WHERE id = ?", row.start, row.end, row.id).Error; err != nil {
+				return err
+			}
+		}
+		statements = []string{
+			"ALTER TABLE task_assignments DROP COLUMN start_date",
+			"ALTER TABLE task_assignments DROP COLUMN end_date",
+			"ALTER TABLE task_assignments RENAME COLUMN start_at TO start_date",
+			"ALTER TABLE task_assignments RENAME COLUMN end_at TO end_date",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateEstimateMinutes fills in the minute estimates of tasks and shares
+// written when estimates were whole hours
+func migrateEstimateMinutes(db *gorm.DB) {
+	statements := []string{
+		"UPDATE tasks SET estimated_minutes = estimated_hours * 60 WHERE estimated_minutes = 0 AND estimated_hours > 0",
+		"UPDATE task_assignments SET minutes = hours * 60 WHERE minutes = 0 AND hours > 0",
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Fatalf("Error migrating estimates to minutes: %v", err)
+		}
+	}
+}
+
+func parseStoredDate(value string, loc *time.Location) (t time.Time, err error) {
+	for _, layout := range dateLayouts {
+		if t, err = time.ParseInLocation(layout, strings.TrimSpace(value), loc); err == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
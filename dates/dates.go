@@ -0,0 +1,105 @@
+package dates
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Layout is LegacyLayout with the offset of the timezone at the given
+// instant, accepted on input so a legacy time can be given unambiguously
+const Layout = "2006-01-02 3:04 PM -07:00"
+
+// LegacyLayout is the layout assignment dates were stored and exchanged in
+// before RFC 3339, interpreted as local time
+const LegacyLayout = "2006-01-02 3:04 PM"
+
+// DayLayout is the ISO 8601 layout of a calendar day
+const DayLayout = "2006-01-02"
+
+// Response formats a client can ask for with the dateFormat query parameter
+// or the X-Date-Format header
+const (
+	// FormatRFC3339 returns date times as RFC 3339, the default
+	FormatRFC3339 = "rfc3339"
+	// FormatLegacy returns date times in LegacyLayout, in the location of
+	// the time formatted, as old clients read them
+	FormatLegacy = "legacy"
+)
+
+// HeaderDateFormat is the request header that selects the response format
+const HeaderDateFormat = "X-Date-Format"
+
+// ErrInvalidDate is returned for a value in none of the accepted layouts
+var ErrInvalidDate = errors.New("invalid date time format")
+
+// offsetLayouts carry their own offset
+var offsetLayouts = []string{time.RFC3339, Layout}
+
+// localLayouts have no offset and are read in the caller's location; a plain
+// ISO date is midnight
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	LegacyLayout,
+	DayLayout,
+}
+
+// parse reads value in the first layout that fits and returns it as written,
+// in its own offset or in loc
+func parse(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range offsetLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidDate
+}
+
+// Parse parses a date time given as RFC 3339, ISO 8601, Layout or the legacy
+// layout and returns it in loc; values without an offset are local to loc
+func Parse(value string, loc *time.Location) (time.Time, error) {
+	t, err := parse(value, loc)
+	if err != nil {
+		return t, err
+	}
+	return t.In(loc), nil
+}
+
+// ParseDay parses a calendar day given in any layout Parse accepts; the day
+// is taken as written and the time of day is dropped
+func ParseDay(value string) (time.Time, error) {
+	t, err := parse(value, time.UTC)
+	if err != nil {
+		return t, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// Format formats t in a response format; anything but FormatLegacy is RFC 3339
+func Format(t time.Time, format string) string {
+	if format == FormatLegacy {
+		return t.Format(LegacyLayout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ResponseFormat returns the response format a client asked for, preferring
+// the dateFormat query parameter over the X-Date-Format header
+func ResponseFormat(c *fiber.Ctx) string {
+	format := c.Query("dateFormat", c.Get(HeaderDateFormat))
+	if strings.EqualFold(format, FormatLegacy) {
+		return FormatLegacy
+	}
+	return FormatRFC3339
+}
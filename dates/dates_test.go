@@ -0,0 +1,59 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.NoError(t, err)
+	want := time.Date(2024, 10, 30, 9, 30, 0, 0, kolkata)
+
+	for _, value := range []string{
+		"2024-10-30T09:30:00+05:30",
+		"2024-10-30T04:00:00Z",
+		"2024-10-30T09:30:00.000+05:30",
+		"2024-10-30T09:30:00",
+		"2024-10-30T09:30",
+		"2024-10-30 09:30",
+		"2024-10-30 9:30 AM +05:30",
+		"2024-10-30 9:30 AM",
+		" 2024-10-30 9:30 AM ",
+	} {
+		got, err := Parse(value, kolkata)
+		if assert.NoError(t, err, value) {
+			assert.True(t, want.Equal(got), "%s: got %v", value, got)
+			assert.Equal(t, kolkata, got.Location(), value)
+		}
+	}
+
+	got, err := Parse("2024-10-30", kolkata)
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 10, 30, 0, 0, 0, 0, kolkata).Equal(got))
+
+	for _, value := range []string{"", "30/10/2024", "2024-13-01", "2024-10-30 25:00"} {
+		_, err := Parse(value, kolkata)
+		assert.ErrorIs(t, err, ErrInvalidDate, value)
+	}
+}
+
+func TestParseDay(t *testing.T) {
+	for _, value := range []string{"2024-10-30", "2024-10-30T23:30:00-08:00", "2024-10-30 9:30 AM"} {
+		day, err := ParseDay(value)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, "2024-10-30", day.Format(DayLayout), value)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.NoError(t, err)
+	at := time.Date(2024, 10, 30, 14, 5, 0, 0, kolkata)
+	assert.Equal(t, "2024-10-30T14:05:00+05:30", Format(at, FormatRFC3339))
+	assert.Equal(t, "2024-10-30T14:05:00+05:30", Format(at, ""))
+	assert.Equal(t, "2024-10-30 2:05 PM", Format(at, FormatLegacy))
+}
@@ -190,7 +190,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new holiday with provided details",
+                "description": "Create a new holiday with provided details; startTime and endTime (HH:MM) close only part of the day, calendarID selects a regional holiday calendar (0 for company-wide)",
                 "consumes": [
                     "application/json"
                 ],
@@ -221,7 +221,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "201": {
-                        "description": "Holiday created successfully",
+                        "description": "Holiday created successfully, with the number of affected assignments",
                         "schema": {
                             "type": "string"
                         }
@@ -233,7 +233,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Holiday already defined",
+                        "description": "Holiday already defined / Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
@@ -241,24 +247,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/holiday/{id}": {
+        "/api/v2/holiday/export": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a holiday by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Export the holiday list, including recurring holidays of the given year, as an iCalendar file",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
                     "Holiday Management"
                 ],
-                "summary": "Get a holiday by ID",
+                "summary": "Export holidays as .ics",
                 "parameters": [
                     {
                         "type": "string",
@@ -269,42 +272,43 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Holiday ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Year to generate recurring holidays for (default current year)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only export holidays of this holiday calendar (0 for the company-wide list)",
+                        "name": "calendarID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Holiday retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.Holiday"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request payload",
+                        "description": "iCalendar file",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Holiday not found",
+                    "400": {
+                        "description": "Invalid year / Invalid calendar ID",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v2/holiday/import": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing holiday by its ID",
+                "description": "Import the events of an uploaded iCalendar file as holidays. All-day events become whole-day holidays (one per day for multi-day events), timed events become partial-day holidays. Times in UTC or with a TZID are converted to the given timezone, and rejected per event without one. Dates already defined in the holiday calendar are reported as conflicts and skipped.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -312,7 +316,7 @@ const docTemplate = `{
                 "tags": [
                     "Holiday Management"
                 ],
-                "summary": "Update a holiday by ID",
+                "summary": "Import holidays from .ics",
                 "parameters": [
                     {
                         "type": "string",
@@ -322,43 +326,61 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Updated holiday details",
-                        "name": "holiday",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Holiday"
-                        }
+                        "type": "file",
+                        "description": "iCalendar file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holiday calendar to import into (default company-wide)",
+                        "name": "calendarID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone the holiday hours are kept in, to convert UTC and TZID times to",
+                        "name": "timezone",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Holiday updated successfully",
+                        "description": "Per event import results",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/holiday.ImportResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid request payload / Invalid iCalendar file / invalid timezone",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Holiday not found",
+                        "description": "Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v2/holiday/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing holiday by its ID",
+                "description": "Retrieve a holiday by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -368,7 +390,7 @@ const docTemplate = `{
                 "tags": [
                     "Holiday Management"
                 ],
-                "summary": "Delete a holiday by ID",
+                "summary": "Get a holiday by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -387,9 +409,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Holiday deleted successfully",
+                        "description": "Holiday retrieved successfully",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Holiday"
                         }
                     },
                     "400": {
@@ -405,23 +427,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v2/refreshToken": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Refreshes the authentication token",
+                "description": "Update an existing holiday by its ID; the whole holiday is replaced, so empty startTime and endTime make it a whole-day holiday again",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Holiday Management"
                 ],
-                "summary": "Refresh authentication token",
+                "summary": "Update a holiday by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -431,39 +454,49 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Refresh user token",
-                        "name": "user",
+                        "description": "Updated holiday details",
+                        "name": "holiday",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Holiday"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token refreshed successfully",
+                        "description": "Holiday updated successfully, with the number of affected assignments",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Holiday / Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/api/v2/task": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve all holidays",
+                "description": "Delete an existing holiday by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -473,7 +506,7 @@ const docTemplate = `{
                 "tags": [
                     "Holiday Management"
                 ],
-                "summary": "Get all holidays",
+                "summary": "Delete a holiday by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -481,24 +514,51 @@ const docTemplate = `{
                         "name": "token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Holiday retrieved successfully",
+                        "description": "Holiday deleted successfully, with the number of affected assignments",
                         "schema": {
-                            "$ref": "#/definitions/models.Holiday"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Holiday not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v2/holidayCalendar": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new task with provided details",
+                "description": "Retrieve all holiday calendars",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,9 +566,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Management"
+                    "Holiday Management"
                 ],
-                "summary": "Create a new task",
+                "summary": "Get all holiday calendars",
                 "parameters": [
                     {
                         "type": "string",
@@ -516,41 +576,24 @@ const docTemplate = `{
                         "name": "token",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Task details",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Task"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Task created successfully",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request payload",
+                    "200": {
+                        "description": "Holiday calendars retrieved successfully",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.HolidayCalendar"
                         }
                     }
                 }
-            }
-        },
-        "/api/v2/task/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a task by its ID",
+                "description": "Create a named holiday calendar (e.g. a city or region) that users can be linked to through their schedule",
                 "consumes": [
                     "application/json"
                 ],
@@ -558,9 +601,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Management"
+                    "Holiday Management"
                 ],
-                "summary": "Get a task by ID",
+                "summary": "Create a holiday calendar",
                 "parameters": [
                     {
                         "type": "string",
@@ -570,18 +613,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Holiday calendar details",
+                        "name": "calendar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HolidayCalendar"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Task retrieved successfully",
+                    "201": {
+                        "description": "Holiday calendar created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/models.HolidayCalendar"
                         }
                     },
                     "400": {
@@ -590,21 +635,23 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Task not found",
+                    "409": {
+                        "description": "Holiday calendar already exists",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v2/holidayCalendar/{id}": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing task by its ID",
+                "description": "Rename an existing holiday calendar",
                 "consumes": [
                     "application/json"
                 ],
@@ -612,9 +659,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Management"
+                    "Holiday Management"
                 ],
-                "summary": "Update a task by ID",
+                "summary": "Update a holiday calendar by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -624,18 +671,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Updated task details",
-                        "name": "task",
+                        "description": "Updated holiday calendar details",
+                        "name": "calendar",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/models.HolidayCalendar"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task updated successfully",
+                        "description": "Holiday calendar updated successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -647,7 +694,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Task not found",
+                        "description": "Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Holiday calendar already exists",
                         "schema": {
                             "type": "string"
                         }
@@ -660,7 +713,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing task by its ID",
+                "description": "Delete a holiday calendar together with its holidays and rules; linked users fall back to the company-wide list",
                 "consumes": [
                     "application/json"
                 ],
@@ -668,9 +721,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Management"
+                    "Holiday Management"
                 ],
-                "summary": "Delete a task by ID",
+                "summary": "Delete a holiday calendar by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -681,7 +734,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Holiday Calendar ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -689,7 +742,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task deleted successfully",
+                        "description": "Holiday calendar deleted successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -701,7 +754,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Task not found",
+                        "description": "Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
@@ -709,14 +768,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/taskAssignment": {
+        "/api/v2/holidayRule": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve all task assignments",
+                "description": "Retrieve all recurring holiday rules",
                 "consumes": [
                     "application/json"
                 ],
@@ -724,9 +783,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Assignment"
+                    "Holiday Management"
                 ],
-                "summary": "Get all task assignments",
+                "summary": "Get all recurring holiday rules",
                 "parameters": [
                     {
                         "type": "string",
@@ -738,9 +797,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task Assignment retrieved successfully",
+                        "description": "Holiday rules retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.TaskAssignment"
+                            "$ref": "#/definitions/models.HolidayRule"
                         }
                     }
                 }
@@ -751,7 +810,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new task assignment with provided details",
+                "description": "Create a rule that generates a holiday every year: \"fixed\" (month, day), \"nthWeekday\" (month, weekday, nth 1-5 or -1 for last) or \"easter\" (offset in days from Easter Sunday)",
                 "consumes": [
                     "application/json"
                 ],
@@ -759,9 +818,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Assignment"
+                    "Holiday Management"
                 ],
-                "summary": "Create a new task assignment",
+                "summary": "Create a recurring holiday rule",
                 "parameters": [
                     {
                         "type": "string",
@@ -771,30 +830,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Task assignment details",
-                        "name": "taskAssignment",
+                        "description": "Holiday rule details",
+                        "name": "rule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TaskAssignment"
+                            "$ref": "#/definitions/models.HolidayRule"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Task assignment created successfully",
+                        "description": "Holiday rule created successfully",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.HolidayRule"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid request payload / Invalid rule",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "409": {
-                        "description": "Username doesn't exist / Task not found / Task is already assigned",
+                    "404": {
+                        "description": "Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
@@ -802,14 +867,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/taskAssignment/{id}": {
+        "/api/v2/holidayRule/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a task assignment by its ID",
+                "description": "Retrieve a recurring holiday rule by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -817,9 +882,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Assignment"
+                    "Holiday Management"
                 ],
-                "summary": "Get a task assignment by ID",
+                "summary": "Get a recurring holiday rule by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -830,7 +895,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Task Assignment ID",
+                        "description": "Holiday Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -838,9 +903,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task assignment retrieved successfully",
+                        "description": "Holiday rule retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.TaskAssignment"
+                            "$ref": "#/definitions/models.HolidayRule"
                         }
                     },
                     "400": {
@@ -850,7 +915,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Task assignment not found",
+                        "description": "Holiday rule not found",
                         "schema": {
                             "type": "string"
                         }
@@ -863,7 +928,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing task assignment by its ID",
+                "description": "Update an existing recurring holiday rule by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -871,9 +936,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Assignment"
+                    "Holiday Management"
                 ],
-                "summary": "Update a task assignment by ID",
+                "summary": "Update a recurring holiday rule by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -883,30 +948,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Updated task assignment details",
-                        "name": "taskAssignment",
+                        "description": "Updated holiday rule details",
+                        "name": "rule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TaskAssignment"
+                            "$ref": "#/definitions/models.HolidayRule"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task assignment updated successfully",
+                        "description": "Holiday rule updated successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid request payload / Invalid rule",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Username doesn't exist / Task not found / Task assignment not found",
+                        "description": "Holiday rule / Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
@@ -919,7 +990,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing task assignment by its ID",
+                "description": "Delete an existing recurring holiday rule by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -927,9 +998,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task Assignment"
+                    "Holiday Management"
                 ],
-                "summary": "Delete a task assignment by ID",
+                "summary": "Delete a recurring holiday rule by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -940,7 +1011,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Task Assignment ID",
+                        "description": "Holiday Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -948,7 +1019,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task assignment deleted successfully",
+                        "description": "Holiday rule deleted successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -960,7 +1031,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Task assignment not found",
+                        "description": "Holiday rule not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
@@ -968,14 +1045,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v2/user": {
-            "put": {
+        "/api/v2/leave": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates the password of the authenticated user",
+                "description": "Retrieve all leaves, optionally for one user",
                 "consumes": [
                     "application/json"
                 ],
@@ -983,9 +1060,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Leave Management"
                 ],
-                "summary": "Update user password",
+                "summary": "Get all leaves",
                 "parameters": [
                     {
                         "type": "string",
@@ -995,56 +1072,162 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update Password Request",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Only list leaves of this user",
+                        "name": "username",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaves retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Leave"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record leave for a user from startDate to endDate (YYYY-MM-DD, inclusive); defaults to the authenticated user. The user's assignments are rescheduled around it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Management"
+                ],
+                "summary": "Create a new leave",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Leave details",
+                        "name": "leave",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Leave"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Password updated successfully",
+                    "201": {
+                        "description": "Leave created successfully",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Leave"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid request payload / invalid date format",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Username doesn't exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Leave overlaps an existing leave",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/leave/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a leave by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Management"
+                ],
+                "summary": "Get a leave by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Leave ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leave retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Leave"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Username doesn't exist",
+                        "description": "Leave not found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes the account of the authenticated user",
+                "description": "Update the dates or reason of an existing leave; the user's assignments are rescheduled",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Leave Management"
                 ],
-                "summary": "Delete user account",
+                "summary": "Update a leave by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -1054,116 +1237,3311 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "User deletion request",
-                        "name": "user",
+                        "description": "Updated leave details",
+                        "name": "leave",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Leave"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Leave updated successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid request payload / invalid date format",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Leave not found",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Username doesn't exist",
+                    "409": {
+                        "description": "Leave overlaps an existing leave",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to reschedule assignments",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.Holiday": {
-            "type": "object",
-            "properties": {
-                "holidayDate": {
-                    "type": "string"
-                },
-                "holidayName": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.Task": {
-            "type": "object",
-            "properties": {
-                "estimatedHours": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.TaskAssignment": {
-            "type": "object",
-            "properties": {
-                "endDate": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "startDate": {
-                    "type": "string"
-                },
-                "taskid": {
-                    "type": "integer"
-                },
-                "username": {
-                    "type": "string"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing leave; the user's assignments are rescheduled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Management"
+                ],
+                "summary": "Delete a leave by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Leave ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leave deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Leave not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/refreshToken": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Refreshes the authentication token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Refresh authentication token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Refresh user token",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/task": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all holidays, including those generated from recurring rules for the given year",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holiday Management"
+                ],
+                "summary": "Get all holidays",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year to generate recurring holidays for (default current year)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list holidays of this holiday calendar (0 for the company-wide list)",
+                        "name": "calendarID",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Holiday retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Holiday"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year / Invalid calendar ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new task with provided details; the estimate is given in estimatedMinutes, or in whole estimatedHours by older clients, and estimatedHours is stored rounded up",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Create a new task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Task details",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / estimate must not be negative",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/task/dependency": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all task dependencies, or those of one task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Get task dependencies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list dependencies of this task",
+                        "name": "taskid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dependencies retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskDependency"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Make a task wait for another one: taskid cannot start until dependsOnID is done. The dependent assignment is moved to start after its predecessor ends, and later again whenever the predecessor comes to end later; it is never moved earlier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Create a task dependency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Dependency details",
+                        "name": "dependency",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskDependency"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Dependency created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskDependency"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / Dependency would create a cycle",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Dependency already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/task/dependency/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a dependency; assignments that were moved for it keep their dates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Delete a task dependency by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Dependency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dependency deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Dependency not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/task/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a task by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Get a task by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing task by its ID; the estimate is given in estimatedMinutes or whole estimatedHours, and when it changes the assignees' shares are scaled with it. estimatedHours equal to the current estimate rounded up to whole hours, without estimatedMinutes, keeps the current estimate. Dependent tasks are moved later when the task comes to end later, never earlier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Update a task by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated task details",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / estimate must not be negative",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing task by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Management"
+                ],
+                "summary": "Delete a task by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all task assignments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Get all task assignments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task Assignment retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskAssignment"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new task assignment with provided details. A task can be shared by several assignees; minutes (or whole hours) is this assignee's share of the estimate (default: all of it not yet shared out) and the task finishes when the last share does.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Create a new task assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Task assignment details",
+                        "name": "taskAssignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.AssignmentRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "parallel",
+                            "queue",
+                            "reject",
+                            "backward"
+                        ],
+                        "type": "string",
+                        "description": "Scheduling mode: parallel (default), queue after the user's existing work, reject overlaps, or backward from endDate to the latest start",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task assignment created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Username doesn't exist / Task not found / Task is already assigned to this user / Task is already fully assigned / Overlaps existing assignments",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/autoSchedule": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Assign tasks (all tasks with hours not yet shared out when taskIDs is empty) to the candidate users; a partly assigned task is planned for its remaining hours, on a candidate who holds no share of it yet. Tasks are placed in dependency order, longest first among those whose predecessors are placed, each on the user who would finish it earliest after their existing work, which balances load and keeps the overall finish date early; no task starts before its predecessors end. With dryRun the plan is returned without creating assignments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Auto schedule unassigned tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Tasks, candidate users, earliest start date and dry run flag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.AutoScheduleRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Planned assignments (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.AutoScheduleResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Assignments created",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.AutoScheduleResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / invalid date time format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/criticalPath": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compute the overall finish date, the slack of every assigned task in working hours (how long it can slip without moving the finish date) and the chain of zero-slack tasks that decides the delivery date. Uses the stored assignment dates and task dependencies.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Critical path analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Critical path computed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.CriticalPathResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/gantt": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return assignment bars grouped by user, with weekends, holidays and leave shaded, as JSON or as a rendered SVG (format=svg). The range defaults to the span of all assignments, cut to a year.",
+                "produces": [
+                    "application/json",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Gantt chart of task assignments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gantt chart data",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.GanttChart"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date format / range",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/latestStart": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Work back from endDate through working hours, breaks, weekends, holidays and leave to the latest start that still finishes estimatedMinutes or estimatedHours (or the task's estimate) by the deadline. Uses the user's working schedule when username is given, otherwise the active working calendar. endDate in the response is when the work actually finishes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Latest start for a deadline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Deadline and estimate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.LatestStartRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Latest start computed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.LatestStartResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / invalid date time format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist / Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/simulate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Apply proposed holidays (added, replaced by id or removed) and task estimate changes in memory, reschedule every assignment as the live update would, including moving dependent tasks, and return each assignment's old and new dates. Nothing is written.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Simulate holiday and estimate changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Proposed changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.SimulationRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Old and new dates of every assignment",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.SimulationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / invalid holiday",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/workingHours": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Count the working time between startDate and endDate, skipping time outside the working day, breaks, weekend days and holidays. Uses the user's working schedule, holiday calendar and leave when username is given, otherwise the active working calendar. The result is negative when endDate is before startDate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Working hours between two timestamps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Timestamps and optional user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.WorkingHoursRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Working hours computed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.WorkingHoursResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / invalid date time format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/taskAssignment/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a task assignment by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Get a task assignment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task Assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task assignment retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task assignment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing task assignment by its ID. Assignments of dependent tasks that would now start before it ends are moved later; they are not moved earlier when it ends earlier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Update a task assignment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated task assignment details",
+                        "name": "taskAssignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskAssignment.AssignmentRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "parallel",
+                            "queue",
+                            "reject",
+                            "backward"
+                        ],
+                        "type": "string",
+                        "description": "Scheduling mode: parallel (default), queue after the user's existing work, reject overlaps, or backward from endDate to the latest start",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task assignment updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist / Task not found / Task assignment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Task is already assigned to this user / Overlaps existing assignments",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing task assignment by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task Assignment"
+                ],
+                "summary": "Delete a task assignment by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task Assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task assignment deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task assignment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/timeEntry": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all time entries, optionally of one user or task, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Get all time entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list entries of this user",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list entries against this task",
+                        "name": "taskid",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entries retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timeEntry.TimeEntryResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Log time the authenticated user spent on one of their task assignments from startTime to endTime. Entries of a user must not overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Log a time entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Time entry details",
+                        "name": "timeEntry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Time entry created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / invalid date time format / end time must be after start time",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Task assignment belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task assignment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlaps an existing time entry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create time entry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/timeEntry/start": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start a timer for the authenticated user against one of their task assignments. Only one timer can run at a time and it must not overlap logged entries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Start a timer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignment and note",
+                        "name": "timeEntry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Timer started successfully",
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Task assignment belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task assignment not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A timer is already running / Overlaps an existing time entry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start timer",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/timeEntry/stop": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stop the authenticated user's running timer, logging the time since it started",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Stop the running timer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timer stopped successfully",
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer is running",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to stop timer",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/timeEntry/timesheet": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Time logged per user and week, per task and per day, with weekends, holidays and leave flagged, as JSON or CSV (format=csv). Weeks run Monday to Sunday in each user's timezone; running timers count up to now.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Weekly timesheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Any day of the first week (YYYY-MM-DD, default today)",
+                        "name": "week",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of weeks (default 1, at most 53)",
+                        "name": "weeks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only report this user (default every user)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheets retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timeEntry.Timesheet"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid date format / Invalid number of weeks",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/timeEntry/totals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Total time logged per task, optionally of one user or task; running timers count up to now",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Logged time per task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count entries of this user",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only count entries against this task",
+                        "name": "taskid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logged time retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timeEntry.TaskTotal"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/timeEntry/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a time entry by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Get a time entry by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rfc3339",
+                            "legacy"
+                        ],
+                        "type": "string",
+                        "description": "Date format of the response, also read from the X-Date-Format header",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entry retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Time entry not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Correct the times or note of one of the authenticated user's entries; times left empty are kept. Entries of a user must not overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Update a time entry by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated time entry details",
+                        "name": "timeEntry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timeEntry.TimeEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entry updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / invalid date time format / end time must be after start time",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Time entry belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Time entry not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlaps an existing time entry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update time entry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete one of the authenticated user's time entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time Tracking"
+                ],
+                "summary": "Delete a time entry by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entry deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Time entry belongs to another user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Time entry not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete time entry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/user": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates the password of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Update user password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Password Request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the account of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Delete user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "User deletion request",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/user/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the working schedule of a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get user working schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Username",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Set the working days (comma separated), hours per day, IANA timezone and holiday calendar of a user; 0 hours means the full calendar day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Set user working schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "User schedule details",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / Invalid schedule / working days must include a working day of the working calendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Username doesn't exist / Holiday calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove the working schedule of a user so the full calendar applies again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Delete user working schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Username",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reschedule assignments",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/workingCalendar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all working calendars",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working Calendar"
+                ],
+                "summary": "Get all working calendars",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Working calendars retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkingCalendar"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a working calendar with daily hours (HH:MM), breaks (HH:MM-HH:MM, comma separated) and weekend days (comma separated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working Calendar"
+                ],
+                "summary": "Create a new working calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Working calendar details",
+                        "name": "calendar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkingCalendar"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Working calendar created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkingCalendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / Invalid calendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/workingCalendar/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a working calendar by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working Calendar"
+                ],
+                "summary": "Get a working calendar by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Working Calendar ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Working calendar retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkingCalendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Working calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace an existing working calendar with the given details; assignment end dates are recomputed when the default calendar changes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working Calendar"
+                ],
+                "summary": "Update a working calendar by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated working calendar details",
+                        "name": "calendar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkingCalendar"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Working calendar updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload / Invalid calendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Working calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing working calendar by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working Calendar"
+                ],
+                "summary": "Delete a working calendar by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Working Calendar ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Working calendar deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Working calendar not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "holiday.ImportResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "holidayDate": {
+                    "type": "string"
+                },
+                "holidayName": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Holiday": {
+            "type": "object",
+            "properties": {
+                "calendarID": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "holidayDate": {
+                    "type": "string"
+                },
+                "holidayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ruleID": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HolidayCalendar": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HolidayRule": {
+            "type": "object",
+            "properties": {
+                "calendarID": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "holidayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "nth": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "ruleType": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Leave": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Task": {
+            "type": "object",
+            "properties": {
+                "estimatedHours": {
+                    "type": "integer"
+                },
+                "estimatedMinutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TaskAssignment": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TaskDependency": {
+            "type": "object",
+            "properties": {
+                "dependsOnID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "taskid": {
+                    "type": "integer"
                 }
             }
         },
         "models.User": {
             "type": "object",
             "properties": {
-                "email": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserSchedule": {
+            "type": "object",
+            "properties": {
+                "holidayCalendarID": {
+                    "type": "integer"
+                },
+                "hoursPerDay": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "workingDays": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WorkingCalendar": {
+            "type": "object",
+            "properties": {
+                "breaks": {
+                    "type": "string"
+                },
+                "dayEnd": {
+                    "type": "string"
+                },
+                "dayStart": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weekendDays": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.AssignmentRequest": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.AutoScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "taskIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "usernames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "taskAssignment.AutoScheduleResult": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.PlannedAssignment"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "finishDate": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.SkippedTask"
+                    }
+                },
+                "userHours": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "userMinutes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "taskAssignment.CriticalPathResult": {
+            "type": "object",
+            "properties": {
+                "criticalPath": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.CriticalTask"
+                    }
+                },
+                "finishDate": {
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.CriticalTask"
+                    }
+                }
+            }
+        },
+        "taskAssignment.CriticalTask": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "boolean"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "slackHours": {
+                    "type": "number"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "usernames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "taskAssignment.GanttBar": {
+            "type": "object",
+            "properties": {
+                "assignmentID": {
+                    "type": "integer"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.GanttChart": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.GanttRow"
+                    }
+                },
+                "shaded": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.ShadedDay"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.GanttRow": {
+            "type": "object",
+            "properties": {
+                "bars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.GanttBar"
+                    }
+                },
+                "shaded": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.ShadedDay"
+                    }
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.LatestStartRequest": {
+            "type": "object",
+            "properties": {
+                "endDate": {
                     "type": "string"
                 },
-                "name": {
+                "estimatedHours": {
+                    "type": "integer"
+                },
+                "estimatedMinutes": {
+                    "type": "integer"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "timezone": {
                     "type": "string"
                 },
-                "password": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.LatestStartResult": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "estimatedHours": {
+                    "type": "integer"
+                },
+                "estimatedMinutes": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.PlannedAssignment": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "estimatedHours": {
+                    "type": "integer"
+                },
+                "estimatedMinutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.ShadedDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.SimulatedAssignment": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "newEndDate": {
+                    "type": "string"
+                },
+                "newStartDate": {
+                    "type": "string"
+                },
+                "oldEndDate": {
+                    "type": "string"
+                },
+                "oldStartDate": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.SimulationRequest": {
+            "type": "object",
+            "properties": {
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Holiday"
+                    }
+                },
+                "removeHolidayIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                }
+            }
+        },
+        "taskAssignment.SimulationResult": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskAssignment.SimulatedAssignment"
+                    }
+                },
+                "changed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "taskAssignment.SkippedTask": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "taskAssignment.WorkingHoursRequest": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskAssignment.WorkingHoursResult": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "timeEntry.TaskTotal": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer"
+                },
+                "estimatedMinutes": {
+                    "type": "integer"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "timeEntry.TimeEntryRequest": {
+            "type": "object",
+            "properties": {
+                "assignmentID": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "timeEntry.TimeEntryResponse": {
+            "type": "object",
+            "properties": {
+                "assignmentID": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "timeEntry.Timesheet": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timeEntry.TimesheetDay"
+                    }
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timeEntry.TimesheetTask"
+                    }
+                },
+                "totalHours": {
+                    "type": "number"
+                },
+                "totalMinutes": {
+                    "type": "integer"
                 },
                 "username": {
                     "type": "string"
+                },
+                "weekStart": {
+                    "type": "string"
+                }
+            }
+        },
+        "timeEntry.TimesheetDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "holiday": {
+                    "type": "boolean"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "leave": {
+                    "type": "boolean"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "weekday": {
+                    "type": "string"
+                },
+                "weekend": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "timeEntry.TimesheetTask": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "minutes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "taskid": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "totalHours": {
+                    "type": "number"
+                },
+                "totalMinutes": {
+                    "type": "integer"
                 }
             }
         }
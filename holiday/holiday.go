@@ -2,18 +2,22 @@ package holiday
 
 import (
 	"encoding/json"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
 	"github.com/saran-crayonte/task/models"
-	"github.com/saran-crayonte/task/task"
+	"github.com/saran-crayonte/task/taskAssignment"
+	"gorm.io/gorm"
 )
 
 // CreateHoliday handles creating a new holiday
 //
 //	@Summary		Create a new holiday
-//	@Description	Create a new holiday with provided details
+//	@Description	Create a new holiday with provided details; startTime and endTime (HH:MM) close only part of the day, calendarID selects a regional holiday calendar (0 for company-wide)
 //	@Tags			Holiday Management
 //	@Accept			json
 //	@Produce		json
@@ -22,9 +26,10 @@ import (
 //	@Param			token	header		string			true	"API Key"
 //
 //	@Param			holiday	body		models.Holiday	true	"Holiday details"
-//	@Success		201		{object}	string			"Holiday created successfully"
+//	@Success		201		{object}	string			"Holiday created successfully, with the number of affected assignments"
 //	@Failure		400		{object}	string			"Invalid request payload"
-//	@Failure		404		{object}	string			"Holiday already defined"
+//	@Failure		404		{object}	string			"Holiday already defined / Holiday calendar not found"
+//	@Failure		500		{object}	string			"Failed to reschedule assignments"
 //	@Router			/api/v2/holiday [post]
 func CreateHoliday() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -32,29 +37,46 @@ func CreateHoliday() fiber.Handler {
 		if err := json.Unmarshal(c.Body(), &holiday); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
 		}
-		layout := "2006-01-02"
-		_, err := time.Parse(layout, holiday.HolidayDate)
+		day, err := dates.ParseDay(string(holiday.HolidayDate))
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
 		}
-		var newHoliday models.Holiday
-		database.DB.Where("holiday_date=?", holiday.HolidayDate).First(&newHoliday)
-		if newHoliday.ID != 0 {
+		holiday.HolidayDate = models.Date(day.Format(dates.DayLayout))
+		if _, _, err := taskAssignment.ParseHolidayHours(*holiday); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !holidayCalendarExists(holiday.CalendarID) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		if existingHolidayOn(database.DB, holiday.HolidayDate, holiday.CalendarID).ID != 0 {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday already defined"})
 		}
-		database.DB.Create(&holiday)
-		UpdateHolidayInAssignment()
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Create(&holiday).Error; err != nil {
+				return nil, err
+			}
+			return []taskAssignment.HolidayChange{{CalendarID: holiday.CalendarID, Date: string(holiday.HolidayDate)}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
 		type UserResponse struct {
-			Message     string `json:"message"`
-			HolidayID   string `json:"holidayID"`
-			HolidayName string `json:"holidayName"`
-			HolidayDate string `json:"holidayDate"`
+			Message             string `json:"message"`
+			HolidayID           string `json:"holidayID"`
+			HolidayName         string `json:"holidayName"`
+			HolidayDate         string `json:"holidayDate"`
+			StartTime           string `json:"startTime"`
+			EndTime             string `json:"endTime"`
+			AffectedAssignments int    `json:"affectedAssignments"`
 		}
 		return c.Status(fiber.StatusCreated).JSON(UserResponse{
-			Message:     "Holiday created successfully",
-			HolidayID:   string(rune(holiday.ID)),
-			HolidayName: holiday.HolidayName,
-			HolidayDate: holiday.HolidayDate,
+			Message:             "Holiday created successfully",
+			HolidayID:           string(rune(holiday.ID)),
+			HolidayName:         holiday.HolidayName,
+			HolidayDate:         string(holiday.HolidayDate),
+			StartTime:           holiday.StartTime,
+			EndTime:             holiday.EndTime,
+			AffectedAssignments: affected,
 		})
 	}
 }
@@ -96,7 +118,7 @@ func GetHoliday() fiber.Handler {
 // UpdateHoliday handles updating a holiday by ID
 //
 //	@Summary		Update a holiday by ID
-//	@Description	Update an existing holiday by its ID
+//	@Description	Update an existing holiday by its ID; the whole holiday is replaced, so empty startTime and endTime make it a whole-day holiday again
 //	@Tags			Holiday Management
 //	@Accept			json
 //	@Produce		json
@@ -105,9 +127,10 @@ func GetHoliday() fiber.Handler {
 //	@Param			token	header		string			true	"API Key"
 //
 //	@Param			holiday	body		models.Holiday	true	"Updated holiday details"
-//	@Success		200		{object}	string			"Holiday updated successfully"
+//	@Success		200		{object}	string			"Holiday updated successfully, with the number of affected assignments"
 //	@Failure		400		{object}	string			"Invalid request payload"
-//	@Failure		404		{object}	string			"Holiday not found"
+//	@Failure		404		{object}	string			"Holiday / Holiday calendar not found"
+//	@Failure		500		{object}	string			"Failed to reschedule assignments"
 //	@Router			/api/v2/holiday/{id} [put]
 func UpdateHoliday() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -120,19 +143,37 @@ func UpdateHoliday() fiber.Handler {
 		if newHoliday.ID == 0 {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday not found"})
 		}
-		layout := "2006-01-02"
-		_, err := time.Parse(layout, holiday.HolidayDate)
+		day, err := dates.ParseDay(string(holiday.HolidayDate))
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
 		}
-		var existingHoliday models.Holiday
-		database.DB.Where("holiday_date=?", holiday.HolidayDate).First(&existingHoliday)
+		holiday.HolidayDate = models.Date(day.Format(dates.DayLayout))
+		if _, _, err := taskAssignment.ParseHolidayHours(*holiday); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !holidayCalendarExists(holiday.CalendarID) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		existingHoliday := existingHolidayOn(database.DB, holiday.HolidayDate, holiday.CalendarID)
 		if existingHoliday.ID != 0 && existingHoliday.ID != holiday.ID {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday already defined"})
 		}
-		database.DB.Model(&newHoliday).Updates(holiday)
-		UpdateHolidayInAssignment()
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Holiday Updated Successfully"})
+		oldDay := taskAssignment.HolidayChange{CalendarID: newHoliday.CalendarID, Date: string(newHoliday.HolidayDate)}
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Model(&newHoliday).Updates(holiday).Error; err != nil {
+				return nil, err
+			}
+			// empty hours turn a part-day holiday back into a whole day, and
+			// the hours are validated as a pair, so both are always written
+			if err := tx.Model(&newHoliday).Select("start_time", "end_time", "calendar_id").Updates(holiday).Error; err != nil {
+				return nil, err
+			}
+			return []taskAssignment.HolidayChange{oldDay, {CalendarID: holiday.CalendarID, Date: string(holiday.HolidayDate)}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Holiday Updated Successfully", "affectedAssignments": affected})
 	}
 }
 
@@ -148,9 +189,10 @@ func UpdateHoliday() fiber.Handler {
 //	@Param			token	header		string	true	"API Key"
 //
 //	@Param			id		path		int		true	"Holiday ID"
-//	@Success		200		{object}	string	"Holiday deleted successfully"
+//	@Success		200		{object}	string	"Holiday deleted successfully, with the number of affected assignments"
 //	@Failure		400		{object}	string	"Invalid request payload"
 //	@Failure		404		{object}	string	"Holiday not found"
+//	@Failure		500		{object}	string	"Failed to reschedule assignments"
 //	@Router			/api/v2/holiday/{id} [delete]
 func DeleteHoliday() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -166,30 +208,46 @@ func DeleteHoliday() fiber.Handler {
 		if newHoliday.ID == 0 {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday not found"})
 		}
-		database.DB.Delete(&newHoliday)
-		UpdateHolidayInAssignment()
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Delete(&newHoliday).Error; err != nil {
+				return nil, err
+			}
+			return []taskAssignment.HolidayChange{{CalendarID: newHoliday.CalendarID, Date: string(newHoliday.HolidayDate)}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"message": "Holiday deleted successfully",
+			"message":             "Holiday deleted successfully",
+			"affectedAssignments": affected,
 		})
 	}
 }
 
-func UpdateHolidayInAssignment() {
-	var taskAssignments []models.TaskAssignment
-	database.DB.Find(&taskAssignments)
-	for _, taskAssignment := range taskAssignments {
-		var findTask models.Task
-		database.DB.Where("id=?", taskAssignment.TaskID).First(&findTask)
-		if findTask.ID != 0 {
-			task.UpdatesInTaskAssignment(findTask.ID, findTask.EstimatedHours)
+// UpdateHolidayInAssignment runs write, which stores a holiday change and
+// returns the days it changed, and recomputes the assignments those days can
+// move in the same transaction, so either both are kept or neither is
+func UpdateHolidayInAssignment(write func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error)) (int, error) {
+	affected := 0
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		changes, err := write(tx)
+		if err != nil {
+			return err
 		}
+		affected, err = taskAssignment.RescheduleForHolidays(tx, changes...)
+		return err
+	})
+	taskAssignment.InvalidateHolidayCache()
+	if err != nil {
+		return 0, err
 	}
+	return affected, nil
 }
 
 // DisplayAllHolidays handles retrieving all holidays
 //
 //	@Summary		Get all holidays
-//	@Description	Retrieve all holidays
+//	@Description	Retrieve all holidays, including those generated from recurring rules for the given year
 //	@Tags			Holiday Management
 //	@Accept			json
 //	@Produce		json
@@ -197,12 +255,56 @@ func UpdateHolidayInAssignment() {
 //	@Security		ApiKeyAuth
 //	@Param			token	header		string			true	"API Key"
 //
+//	@Param			year		query		int				false	"Year to generate recurring holidays for (default current year)"
+//	@Param			calendarID	query		int				false	"Only list holidays of this holiday calendar (0 for the company-wide list)"
+//
 //	@Success		200		{object}	models.Holiday	"Holiday retrieved successfully"
+//	@Failure		400		{object}	string			"Invalid year / Invalid calendar ID"
 //	@Router			/api/v2/task [get]
 func DisplayAllHolidays() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		var holiday []models.Holiday
-		database.DB.Find(&holiday)
+		year := time.Now().Year()
+		if c.Query("year") != "" {
+			y, err := strconv.Atoi(c.Query("year"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid year"})
+			}
+			year = y
+		}
+		holiday, err := holidayList(year, c.Query("calendarID"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid calendar ID"})
+		}
 		return c.Status(fiber.StatusOK).JSON(holiday)
 	}
 }
+
+// holidayList returns the stored holidays and the recurring ones generated for
+// year, sorted by date and optionally limited to one holiday calendar
+func holidayList(year int, calendarID string) ([]models.Holiday, error) {
+	holidayQuery := database.DB
+	ruleQuery := database.DB
+	if calendarID != "" {
+		id, err := strconv.Atoi(calendarID)
+		if err != nil {
+			return nil, err
+		}
+		holidayQuery = holidayQuery.Where("calendar_id=?", id)
+		ruleQuery = ruleQuery.Where("calendar_id=?", id)
+	}
+	var holiday []models.Holiday
+	holidayQuery.Find(&holiday)
+	var rules []models.HolidayRule
+	ruleQuery.Find(&rules)
+	holiday = append(holiday, taskAssignment.ExpandHolidayRules(rules, year)...)
+	sort.SliceStable(holiday, func(i, j int) bool { return holiday[i].HolidayDate < holiday[j].HolidayDate })
+	return holiday, nil
+}
+
+// existingHolidayOn returns the holiday already defined on date in a holiday
+// calendar, or an empty holiday when the date is free
+func existingHolidayOn(db *gorm.DB, date models.Date, calendarID uint) models.Holiday {
+	var holiday models.Holiday
+	db.Where("holiday_date=? AND calendar_id=?", date, calendarID).First(&holiday)
+	return holiday
+}
@@ -0,0 +1,177 @@
+package holiday
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+	"gorm.io/gorm"
+)
+
+// CreateHolidayCalendar handles creating a new regional holiday calendar
+//
+//	@Summary		Create a holiday calendar
+//	@Description	Create a named holiday calendar (e.g. a city or region) that users can be linked to through their schedule
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string					true	"API Key"
+//
+//	@Param			calendar	body		models.HolidayCalendar	true	"Holiday calendar details"
+//	@Success		201			{object}	models.HolidayCalendar	"Holiday calendar created successfully"
+//	@Failure		400			{object}	string					"Invalid request payload"
+//	@Failure		409			{object}	string					"Holiday calendar already exists"
+//	@Router			/api/v2/holidayCalendar [post]
+func CreateHolidayCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		calendar := new(models.HolidayCalendar)
+		if err := json.Unmarshal(c.Body(), &calendar); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if calendar.Name == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var existingCalendar models.HolidayCalendar
+		database.DB.Where("name=?", calendar.Name).First(&existingCalendar)
+		if existingCalendar.ID != 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Holiday calendar already exists"})
+		}
+		calendar.ID = 0
+		database.DB.Create(&calendar)
+		return c.Status(fiber.StatusCreated).JSON(calendar)
+	}
+}
+
+// UpdateHolidayCalendar handles renaming a holiday calendar by ID
+//
+//	@Summary		Update a holiday calendar by ID
+//	@Description	Rename an existing holiday calendar
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string					true	"API Key"
+//
+//	@Param			calendar	body		models.HolidayCalendar	true	"Updated holiday calendar details"
+//	@Success		200			{object}	string					"Holiday calendar updated successfully"
+//	@Failure		400			{object}	string					"Invalid request payload"
+//	@Failure		404			{object}	string					"Holiday calendar not found"
+//	@Failure		409			{object}	string					"Holiday calendar already exists"
+//	@Router			/api/v2/holidayCalendar/{id} [put]
+func UpdateHolidayCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		calendar := new(models.HolidayCalendar)
+		if err := json.Unmarshal(c.Body(), &calendar); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if calendar.Name == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var existingCalendar models.HolidayCalendar
+		database.DB.Where("id=?", calendar.ID).First(&existingCalendar)
+		if existingCalendar.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		var sameName models.HolidayCalendar
+		database.DB.Where("name=?", calendar.Name).First(&sameName)
+		if sameName.ID != 0 && sameName.ID != calendar.ID {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Holiday calendar already exists"})
+		}
+		database.DB.Model(&existingCalendar).Updates(calendar)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Holiday calendar updated successfully"})
+	}
+}
+
+// DeleteHolidayCalendar handles deleting a holiday calendar by ID
+//
+//	@Summary		Delete a holiday calendar by ID
+//	@Description	Delete a holiday calendar together with its holidays and rules; linked users fall back to the company-wide list
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string	true	"API Key"
+//
+//	@Param			id		path		int		true	"Holiday Calendar ID"
+//	@Success		200		{object}	string	"Holiday calendar deleted successfully"
+//	@Failure		400		{object}	string	"Invalid request payload"
+//	@Failure		404		{object}	string	"Holiday calendar not found"
+//	@Failure		500		{object}	string	"Failed to reschedule assignments"
+//	@Router			/api/v2/holidayCalendar/{id} [delete]
+func DeleteHolidayCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var calendar models.HolidayCalendar
+		database.DB.Where("id=?", b.ID).First(&calendar)
+		if calendar.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Where("calendar_id=?", calendar.ID).Delete(&models.Holiday{}).Error; err != nil {
+				return nil, err
+			}
+			if err := tx.Where("calendar_id=?", calendar.ID).Delete(&models.HolidayRule{}).Error; err != nil {
+				return nil, err
+			}
+			if err := tx.Model(&models.UserSchedule{}).Where("calendar_id=?", calendar.ID).Update("calendar_id", 0).Error; err != nil {
+				return nil, err
+			}
+			if err := tx.Delete(&calendar).Error; err != nil {
+				return nil, err
+			}
+			// the calendar's users fall back to the company-wide holidays
+			return []taskAssignment.HolidayChange{{CalendarID: 0}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message":             "Holiday calendar deleted successfully",
+			"affectedAssignments": affected,
+		})
+	}
+}
+
+// DisplayAllHolidayCalendars handles retrieving all holiday calendars
+//
+//	@Summary		Get all holiday calendars
+//	@Description	Retrieve all holiday calendars
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string					true	"API Key"
+//
+//	@Success		200		{object}	models.HolidayCalendar	"Holiday calendars retrieved successfully"
+//	@Router			/api/v2/holidayCalendar [get]
+func DisplayAllHolidayCalendars() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var calendars []models.HolidayCalendar
+		database.DB.Find(&calendars)
+		return c.Status(fiber.StatusOK).JSON(calendars)
+	}
+}
+
+// holidayCalendarExists reports whether id names a holiday calendar; 0 is the
+// company-wide list and always exists
+func holidayCalendarExists(id uint) bool {
+	if id == 0 {
+		return true
+	}
+	var calendar models.HolidayCalendar
+	database.DB.Where("id=?", id).First(&calendar)
+	return calendar.ID != 0
+}
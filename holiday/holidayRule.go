@@ -0,0 +1,207 @@
+package holiday
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+	"gorm.io/gorm"
+)
+
+// CreateHolidayRule handles creating a new recurring holiday rule
+//
+//	@Summary		Create a recurring holiday rule
+//	@Description	Create a rule that generates a holiday every year: "fixed" (month, day), "nthWeekday" (month, weekday, nth 1-5 or -1 for last) or "easter" (offset in days from Easter Sunday)
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string				true	"API Key"
+//
+//	@Param			rule	body		models.HolidayRule	true	"Holiday rule details"
+//	@Success		201		{object}	models.HolidayRule	"Holiday rule created successfully"
+//	@Failure		400		{object}	string				"Invalid request payload / Invalid rule"
+//	@Failure		404		{object}	string				"Holiday calendar not found"
+//	@Failure		500		{object}	string				"Failed to reschedule assignments"
+//	@Router			/api/v2/holidayRule [post]
+func CreateHolidayRule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rule := new(models.HolidayRule)
+		if err := json.Unmarshal(c.Body(), &rule); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if rule.HolidayName == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if err := taskAssignment.ValidateHolidayRule(*rule); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !holidayCalendarExists(rule.CalendarID) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		rule.ID = 0
+		_, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Create(&rule).Error; err != nil {
+				return nil, err
+			}
+			return []taskAssignment.HolidayChange{{CalendarID: rule.CalendarID}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusCreated).JSON(rule)
+	}
+}
+
+// GetHolidayRule handles retrieving a recurring holiday rule by ID
+//
+//	@Summary		Get a recurring holiday rule by ID
+//	@Description	Retrieve a recurring holiday rule by its ID
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string				true	"API Key"
+//
+//	@Param			id		path		int					true	"Holiday Rule ID"
+//	@Success		200		{object}	models.HolidayRule	"Holiday rule retrieved successfully"
+//	@Failure		400		{object}	string				"Invalid request payload"
+//	@Failure		404		{object}	string				"Holiday rule not found"
+//	@Router			/api/v2/holidayRule/{id} [get]
+func GetHolidayRule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var rule models.HolidayRule
+		database.DB.Where("id=?", b.ID).First(&rule)
+		if rule.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday rule not found"})
+		}
+		return c.Status(fiber.StatusOK).JSON(rule)
+	}
+}
+
+// UpdateHolidayRule handles updating a recurring holiday rule by ID
+//
+//	@Summary		Update a recurring holiday rule by ID
+//	@Description	Update an existing recurring holiday rule by its ID
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string				true	"API Key"
+//
+//	@Param			rule	body		models.HolidayRule	true	"Updated holiday rule details"
+//	@Success		200		{object}	string				"Holiday rule updated successfully"
+//	@Failure		400		{object}	string				"Invalid request payload / Invalid rule"
+//	@Failure		404		{object}	string				"Holiday rule / Holiday calendar not found"
+//	@Failure		500		{object}	string				"Failed to reschedule assignments"
+//	@Router			/api/v2/holidayRule/{id} [put]
+func UpdateHolidayRule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rule := new(models.HolidayRule)
+		if err := json.Unmarshal(c.Body(), &rule); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var existingRule models.HolidayRule
+		database.DB.Where("id=?", rule.ID).First(&existingRule)
+		if existingRule.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday rule not found"})
+		}
+		if err := taskAssignment.ValidateHolidayRule(*rule); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !holidayCalendarExists(rule.CalendarID) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		oldCalendar := taskAssignment.HolidayChange{CalendarID: existingRule.CalendarID}
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Model(&existingRule).Select("*").Updates(rule).Error; err != nil {
+				return nil, err
+			}
+			return []taskAssignment.HolidayChange{oldCalendar, {CalendarID: rule.CalendarID}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Holiday rule updated successfully", "affectedAssignments": affected})
+	}
+}
+
+// DeleteHolidayRule handles deleting a recurring holiday rule by ID
+//
+//	@Summary		Delete a recurring holiday rule by ID
+//	@Description	Delete an existing recurring holiday rule by its ID
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string	true	"API Key"
+//
+//	@Param			id		path		int		true	"Holiday Rule ID"
+//	@Success		200		{object}	string	"Holiday rule deleted successfully"
+//	@Failure		400		{object}	string	"Invalid request payload"
+//	@Failure		404		{object}	string	"Holiday rule not found"
+//	@Failure		500		{object}	string	"Failed to reschedule assignments"
+//	@Router			/api/v2/holidayRule/{id} [delete]
+func DeleteHolidayRule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var rule models.HolidayRule
+		database.DB.Where("id=?", b.ID).First(&rule)
+		if rule.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday rule not found"})
+		}
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			if err := tx.Delete(&rule).Error; err != nil {
+				return nil, err
+			}
+			return []taskAssignment.HolidayChange{{CalendarID: rule.CalendarID}}, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message":             "Holiday rule deleted successfully",
+			"affectedAssignments": affected,
+		})
+	}
+}
+
+// DisplayAllHolidayRules handles retrieving all recurring holiday rules
+//
+//	@Summary		Get all recurring holiday rules
+//	@Description	Retrieve all recurring holiday rules
+//	@Tags			Holiday Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string				true	"API Key"
+//
+//	@Success		200		{object}	models.HolidayRule	"Holiday rules retrieved successfully"
+//	@Router			/api/v2/holidayRule [get]
+func DisplayAllHolidayRules() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var rules []models.HolidayRule
+		database.DB.Find(&rules)
+		return c.Status(fiber.StatusOK).JSON(rules)
+	}
+}
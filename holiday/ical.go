@@ -0,0 +1,369 @@
+package holiday
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+	"gorm.io/gorm"
+)
+
+// icsEvent is a VEVENT read from an iCalendar file
+type icsEvent struct {
+	UID       string
+	Summary   string
+	Start     string
+	End       string
+	StartTZID string
+	EndTZID   string
+}
+
+// ImportResult reports what happened to one event of an imported .ics file
+type ImportResult struct {
+	UID         string `json:"uid"`
+	HolidayName string `json:"holidayName"`
+	HolidayDate string `json:"holidayDate"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ImportHolidays handles importing holidays from an iCalendar file
+//
+//	@Summary		Import holidays from .ics
+//	@Description	Import the events of an uploaded iCalendar file as holidays. All-day events become whole-day holidays (one per day for multi-day events), timed events become partial-day holidays. Times in UTC or with a TZID are converted to the given timezone, and rejected per event without one. Dates already defined in the holiday calendar are reported as conflicts and skipped.
+//	@Tags			Holiday Management
+//	@Accept			multipart/form-data
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string			true	"API Key"
+//
+//	@Param			file		formData	file			true	"iCalendar file"
+//	@Param			calendarID	query		int				false	"Holiday calendar to import into (default company-wide)"
+//	@Param			timezone	query		string			false	"IANA timezone the holiday hours are kept in, to convert UTC and TZID times to"
+//	@Success		200			{object}	ImportResult	"Per event import results"
+//	@Failure		400			{object}	string			"Invalid request payload / Invalid iCalendar file / invalid timezone"
+//	@Failure		404			{object}	string			"Holiday calendar not found"
+//	@Failure		500			{object}	string			"Failed to reschedule assignments"
+//	@Router			/api/v2/holiday/import [post]
+func ImportHolidays() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var calendarID uint
+		if c.Query("calendarID") != "" {
+			id, err := strconv.Atoi(c.Query("calendarID"))
+			if err != nil || id < 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid calendar ID"})
+			}
+			calendarID = uint(id)
+		}
+		if !holidayCalendarExists(calendarID) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+		}
+		var loc *time.Location
+		if c.Query("timezone") != "" {
+			var err error
+			if loc, err = taskAssignment.LoadLocation(c.Query("timezone")); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+
+		var reader io.Reader = strings.NewReader(string(c.Body()))
+		if fileHeader, err := c.FormFile("file"); err == nil {
+			file, err := fileHeader.Open()
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+			}
+			defer file.Close()
+			reader = file
+		}
+		events, err := parseICS(reader)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		var results []ImportResult
+		created, conflicts := 0, 0
+		affected, err := UpdateHolidayInAssignment(func(tx *gorm.DB) ([]taskAssignment.HolidayChange, error) {
+			var changes []taskAssignment.HolidayChange
+			for _, event := range events {
+				holidays, err := eventHolidays(event, loc)
+				if err != nil {
+					results = append(results, ImportResult{UID: event.UID, HolidayName: event.Summary, Status: "invalid", Error: err.Error()})
+					continue
+				}
+				for _, holiday := range holidays {
+					holiday.CalendarID = calendarID
+					result := ImportResult{UID: event.UID, HolidayName: holiday.HolidayName, HolidayDate: string(holiday.HolidayDate)}
+					if existingHolidayOn(tx, holiday.HolidayDate, calendarID).ID != 0 {
+						result.Status = "conflict"
+						result.Error = "Holiday already defined"
+						conflicts++
+					} else {
+						if err := tx.Create(&holiday).Error; err != nil {
+							return nil, err
+						}
+						changes = append(changes, taskAssignment.HolidayChange{CalendarID: calendarID, Date: string(holiday.HolidayDate)})
+						result.Status = "created"
+						created++
+					}
+					results = append(results, result)
+				}
+			}
+			return changes, nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message":             "Holidays imported",
+			"created":             created,
+			"conflicts":           conflicts,
+			"affectedAssignments": affected,
+			"events":              results,
+		})
+	}
+}
+
+// ExportHolidays handles exporting the holiday list as an iCalendar file
+//
+//	@Summary		Export holidays as .ics
+//	@Description	Export the holiday list, including recurring holidays of the given year, as an iCalendar file
+//	@Tags			Holiday Management
+//	@Produce		text/calendar
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string	true	"API Key"
+//
+//	@Param			year		query		int		false	"Year to generate recurring holidays for (default current year)"
+//	@Param			calendarID	query		int		false	"Only export holidays of this holiday calendar (0 for the company-wide list)"
+//	@Success		200			{string}	string	"iCalendar file"
+//	@Failure		400			{object}	string	"Invalid year / Invalid calendar ID"
+//	@Router			/api/v2/holiday/export [get]
+func ExportHolidays() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		year := time.Now().Year()
+		if c.Query("year") != "" {
+			y, err := strconv.Atoi(c.Query("year"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid year"})
+			}
+			year = y
+		}
+		holidays, err := holidayList(year, c.Query("calendarID"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid calendar ID"})
+		}
+		c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="holidays.ics"`)
+		return c.Status(fiber.StatusOK).SendString(formatICS(holidays, time.Now().UTC()))
+	}
+}
+
+// parseICS reads the VEVENTs of an iCalendar stream, unfolding continued lines
+func parseICS(r io.Reader) ([]icsEvent, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New("Invalid iCalendar file")
+	}
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "BEGIN:VCALENDAR" {
+		return nil, errors.New("Invalid iCalendar file")
+	}
+
+	var events []icsEvent
+	var event *icsEvent
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// keep TZID and drop other parameters such as DTSTART;VALUE=DATE
+		name, params, _ := strings.Cut(name, ";")
+		var tzid string
+		for _, param := range strings.Split(params, ";") {
+			if key, v, ok := strings.Cut(param, "="); ok && strings.EqualFold(key, "TZID") {
+				tzid = strings.Trim(v, `"`)
+			}
+		}
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if value == "VEVENT" {
+				event = &icsEvent{}
+			}
+		case "END":
+			if value == "VEVENT" && event != nil {
+				events = append(events, *event)
+				event = nil
+			}
+		case "UID":
+			if event != nil {
+				event.UID = value
+			}
+		case "SUMMARY":
+			if event != nil {
+				event.Summary = unescapeICS(value)
+			}
+		case "DTSTART":
+			if event != nil {
+				event.Start, event.StartTZID = value, tzid
+			}
+		case "DTEND":
+			if event != nil {
+				event.End, event.EndTZID = value, tzid
+			}
+		}
+	}
+	return events, nil
+}
+
+// eventHolidays converts an event into holidays: one per day for all-day
+// events (DTEND is exclusive) and a partial-day holiday for timed events,
+// whose hours are wall-clock times in loc
+func eventHolidays(event icsEvent, loc *time.Location) ([]models.Holiday, error) {
+	if event.Summary == "" {
+		return nil, errors.New("event has no summary")
+	}
+	if len(event.Start) == 8 {
+		start, err := time.Parse("20060102", event.Start)
+		if err != nil {
+			return nil, errors.New("invalid DTSTART")
+		}
+		end := start.AddDate(0, 0, 1)
+		if event.End != "" {
+			if end, err = time.Parse("20060102", event.End); err != nil || !end.After(start) {
+				return nil, errors.New("invalid DTEND")
+			}
+		}
+		var holidays []models.Holiday
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			holidays = append(holidays, models.Holiday{HolidayName: event.Summary, HolidayDate: models.Date(day.Format("2006-01-02"))})
+		}
+		return holidays, nil
+	}
+
+	start, err := icsTime(event.Start, event.StartTZID, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART: %v", err)
+	}
+	end, err := icsTime(event.End, event.EndTZID, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTEND: %v", err)
+	}
+	if !end.After(start) {
+		return nil, errors.New("invalid DTEND")
+	}
+	if end.Format("20060102") != start.Format("20060102") && !(end.Hour() == 0 && end.Minute() == 0 && end.Sub(start) <= 24*time.Hour) {
+		return nil, errors.New("timed events must start and end on the same day")
+	}
+	holiday := models.Holiday{
+		HolidayName: event.Summary,
+		HolidayDate: models.Date(start.Format("2006-01-02")),
+		StartTime:   start.Format("15:04"),
+		EndTime:     end.Format("15:04"),
+	}
+	if end.Format("20060102") != start.Format("20060102") {
+		holiday.EndTime = ""
+	}
+	if _, _, err := taskAssignment.ParseHolidayHours(holiday); err != nil {
+		return nil, err
+	}
+	return []models.Holiday{holiday}, nil
+}
+
+// icsTime reads a DATE-TIME value as a wall-clock time: floating times are
+// taken as written, UTC and TZID times are converted to loc, which they need
+func icsTime(value, tzid string, loc *time.Location) (time.Time, error) {
+	if !strings.HasSuffix(value, "Z") && tzid == "" {
+		t, err := time.Parse("20060102T150405", value)
+		if err != nil {
+			return t, errors.New("invalid date time")
+		}
+		return t, nil
+	}
+	if loc == nil {
+		return time.Time{}, errors.New("UTC and TZID times need a timezone to convert to")
+	}
+	zone := time.UTC
+	if !strings.HasSuffix(value, "Z") {
+		var err error
+		if zone, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, errors.New("unknown TZID " + tzid)
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", strings.TrimSuffix(value, "Z"), zone)
+	if err != nil {
+		return t, errors.New("invalid date time")
+	}
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), nil
+}
+
+// formatICS renders holidays as an iCalendar document
+func formatICS(holidays []models.Holiday, stamp time.Time) string {
+	var b strings.Builder
+	write := func(line string) {
+		// fold lines longer than 75 octets as required by RFC 5545
+		for len(line) > 75 {
+			cut := 75
+			for !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			b.WriteString(line[:cut] + "\r\n")
+			line = " " + line[cut:]
+		}
+		b.WriteString(line + "\r\n")
+	}
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//saran-crayonte//task//EN")
+	write("CALSCALE:GREGORIAN")
+	for _, holiday := range holidays {
+		date, err := time.Parse("2006-01-02", string(holiday.HolidayDate))
+		if err != nil {
+			continue
+		}
+		uid := fmt.Sprintf("holiday-%d@task", holiday.ID)
+		if holiday.RuleID != 0 {
+			uid = fmt.Sprintf("holiday-rule-%d-%s@task", holiday.RuleID, date.Format("20060102"))
+		}
+		write("BEGIN:VEVENT")
+		write("UID:" + uid)
+		write("DTSTAMP:" + stamp.Format("20060102T150405Z"))
+		write("SUMMARY:" + escapeICS(holiday.HolidayName))
+		if holiday.StartTime == "" && holiday.EndTime == "" {
+			write("DTSTART;VALUE=DATE:" + date.Format("20060102"))
+			write("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			start, end, _ := taskAssignment.ParseHolidayHours(holiday)
+			write("DTSTART:" + date.Add(start).Format("20060102T150405"))
+			write("DTEND:" + date.Add(end).Format("20060102T150405"))
+		}
+		write("END:VEVENT")
+	}
+	write("END:VCALENDAR")
+	return b.String()
+}
+
+func escapeICS(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+}
+
+func unescapeICS(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(value)
+}
@@ -0,0 +1,123 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saran-crayonte/task/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:one@example",
+		"SUMMARY:Long name that is",
+		"  folded\\, with an escaped comma",
+		"DTSTART;VALUE=DATE:20241230",
+		"DTEND;VALUE=DATE:20250102",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:two@example",
+		"SUMMARY:Offsite",
+		`DTSTART;TZID="Asia/Kolkata":20241030T130000`,
+		"DTEND;TZID=Asia/Kolkata:20241030T170000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	events, err := parseICS(strings.NewReader(ics))
+	assert.NoError(t, err)
+	assert.Equal(t, []icsEvent{
+		{UID: "one@example", Summary: "Long name that is folded, with an escaped comma", Start: "20241230", End: "20250102"},
+		{UID: "two@example", Summary: "Offsite", Start: "20241030T130000", End: "20241030T170000", StartTZID: "Asia/Kolkata", EndTZID: "Asia/Kolkata"},
+	}, events)
+
+	_, err = parseICS(strings.NewReader("BEGIN:VEVENT\r\nEND:VEVENT\r\n"))
+	assert.Error(t, err)
+}
+
+func TestEventHolidays(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	assert.NoError(t, err)
+	days := func(holidays []models.Holiday) []string {
+		var result []string
+		for _, holiday := range holidays {
+			result = append(result, string(holiday.HolidayDate)+" "+holiday.StartTime+"-"+holiday.EndTime)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name  string
+		event icsEvent
+		loc   *time.Location
+		want  []string
+		err   bool
+	}{
+		{"all day", icsEvent{Start: "20241030"}, nil, []string{"2024-10-30 -"}, false},
+		{"multi-day over the new year", icsEvent{Start: "20241230", End: "20250102"}, nil, []string{"2024-12-30 -", "2024-12-31 -", "2025-01-01 -"}, false},
+		{"empty all day range", icsEvent{Start: "20241030", End: "20241030"}, nil, nil, true},
+		{"floating", icsEvent{Start: "20241030T130000", End: "20241030T180000"}, nil, []string{"2024-10-30 13:00-18:00"}, false},
+		{"until midnight", icsEvent{Start: "20241030T140000", End: "20241031T000000"}, nil, []string{"2024-10-30 14:00-"}, false},
+		{"utc", icsEvent{Start: "20241030T073000Z", End: "20241030T113000Z"}, kolkata, []string{"2024-10-30 13:00-17:00"}, false},
+		{"utc without timezone", icsEvent{Start: "20241030T073000Z", End: "20241030T113000Z"}, nil, nil, true},
+		{"tzid", icsEvent{Start: "20241030T090000", End: "20241030T110000", StartTZID: "Europe/Berlin", EndTZID: "Europe/Berlin"}, kolkata, []string{"2024-10-30 13:30-15:30"}, false},
+		{"unknown tzid", icsEvent{Start: "20241030T090000", End: "20241030T110000", StartTZID: "Nowhere/City", EndTZID: "Nowhere/City"}, kolkata, nil, true},
+		{"across days", icsEvent{Start: "20241030T220000", End: "20241031T020000"}, nil, nil, true},
+		{"backwards", icsEvent{Start: "20241030T150000", End: "20241030T090000"}, nil, nil, true},
+	}
+	for _, test := range tests {
+		test.event.Summary = "Holiday"
+		holidays, err := eventHolidays(test.event, test.loc)
+		if test.err {
+			assert.Error(t, err, test.name)
+			continue
+		}
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.want, days(holidays), test.name)
+		}
+	}
+
+	_, err = eventHolidays(icsEvent{Start: "20241030"}, nil)
+	assert.Error(t, err, "an event needs a summary")
+}
+
+func TestFormatICS(t *testing.T) {
+	stamp := time.Date(2024, 10, 1, 8, 0, 0, 0, time.UTC)
+	ics := formatICS([]models.Holiday{
+		{ID: 1, HolidayName: "Diwali; festival of lights, " + strings.Repeat("long ", 14), HolidayDate: "2024-11-01"},
+		{ID: 2, HolidayName: "Offsite", HolidayDate: "2024-10-30", StartTime: "13:00", EndTime: "18:00"},
+		{RuleID: 3, HolidayName: "Easter", HolidayDate: "2024-03-31"},
+	}, stamp)
+
+	lines := strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n")
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+	assert.Contains(t, lines, "DTSTART;VALUE=DATE:20241101")
+	assert.Contains(t, lines, "DTEND;VALUE=DATE:20241102")
+	assert.Contains(t, lines, "DTSTART:20241030T130000")
+	assert.Contains(t, lines, "DTEND:20241030T180000")
+	assert.Contains(t, lines, "UID:holiday-rule-3-20240331@task")
+	assert.Contains(t, lines, "DTSTAMP:20241001T080000Z")
+
+	// what is written reads back the same
+	events, err := parseICS(strings.NewReader(ics))
+	assert.NoError(t, err)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, "Diwali; festival of lights, "+strings.Repeat("long ", 14), events[0].Summary)
+		holidays, err := eventHolidays(events[1], nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Holiday{{HolidayName: "Offsite", HolidayDate: "2024-10-30", StartTime: "13:00", EndTime: "18:00"}}, holidays)
+	}
+}
+
+func TestEscapeICS(t *testing.T) {
+	for _, value := range []string{`plain`, `a, b; c`, `back\slash`, "two\nlines", `\n literally`} {
+		assert.Equal(t, value, unescapeICS(escapeICS(value)), value)
+	}
+	assert.Equal(t, `a\, b\; c\\d\n`, escapeICS("a, b; c\\d\n"))
+}
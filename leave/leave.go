@@ -0,0 +1,259 @@
+package leave
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+)
+
+// CreateLeave handles creating a new leave entry for a user
+//
+//	@Summary		Create a new leave
+//	@Description	Record leave for a user from startDate to endDate (YYYY-MM-DD, inclusive); defaults to the authenticated user. The user's assignments are rescheduled around it.
+//	@Tags			Leave Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string			true	"API Key"
+//
+//	@Param			leave	body		models.Leave	true	"Leave details"
+//	@Success		201		{object}	models.Leave	"Leave created successfully"
+//	@Failure		400		{object}	string			"Invalid request payload / invalid date format"
+//	@Failure		404		{object}	string			"Username doesn't exist"
+//	@Failure		409		{object}	string			"Leave overlaps an existing leave"
+//	@Failure		500		{object}	string			"Failed to reschedule assignments"
+//	@Router			/api/v2/leave [post]
+func CreateLeave() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		leave := new(models.Leave)
+		if err := json.Unmarshal(c.Body(), &leave); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if leave.Username == "" {
+			leave.Username, _ = c.Locals("username").(string)
+		}
+
+		var existingUser models.User
+		database.DB.Where("username=?", leave.Username).First(&existingUser)
+		if len(existingUser.Username) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Username doesn't exists"})
+		}
+
+		startDate, _, err := leaveDates(leave)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date format"})
+		}
+		if overlapsLeave(*leave) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Leave overlaps an existing leave"})
+		}
+
+		leave.ID = 0
+		database.DB.Create(&leave)
+		if err := updateLeaveInAssignment(leave.Username, startDate); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusCreated).JSON(leave)
+	}
+}
+
+// GetLeave handles retrieving a leave by ID
+//
+//	@Summary		Get a leave by ID
+//	@Description	Retrieve a leave by its ID
+//	@Tags			Leave Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string			true	"API Key"
+//
+//	@Param			id		path		int				true	"Leave ID"
+//	@Success		200		{object}	models.Leave	"Leave retrieved successfully"
+//	@Failure		400		{object}	string			"Invalid request payload"
+//	@Failure		404		{object}	string			"Leave not found"
+//	@Router			/api/v2/leave/{id} [get]
+func GetLeave() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var leave models.Leave
+		database.DB.Where("id=?", b.ID).First(&leave)
+		if leave.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Leave not found"})
+		}
+		return c.Status(fiber.StatusOK).JSON(leave)
+	}
+}
+
+// UpdateLeave handles updating a leave by ID
+//
+//	@Summary		Update a leave by ID
+//	@Description	Update the dates or reason of an existing leave; the user's assignments are rescheduled
+//	@Tags			Leave Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string			true	"API Key"
+//
+//	@Param			leave	body		models.Leave	true	"Updated leave details"
+//	@Success		200		{object}	string			"Leave updated successfully"
+//	@Failure		400		{object}	string			"Invalid request payload / invalid date format"
+//	@Failure		404		{object}	string			"Leave not found"
+//	@Failure		409		{object}	string			"Leave overlaps an existing leave"
+//	@Failure		500		{object}	string			"Failed to reschedule assignments"
+//	@Router			/api/v2/leave/{id} [put]
+func UpdateLeave() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		leave := new(models.Leave)
+		if err := json.Unmarshal(c.Body(), &leave); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var existingLeave models.Leave
+		database.DB.Where("id=?", leave.ID).First(&existingLeave)
+		if existingLeave.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Leave not found"})
+		}
+		leave.Username = existingLeave.Username
+
+		startDate, _, err := leaveDates(leave)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date format"})
+		}
+		if overlapsLeave(*leave) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Leave overlaps an existing leave"})
+		}
+
+		oldStart, _, _ := leaveDates(&existingLeave)
+		if oldStart.Before(startDate) {
+			startDate = oldStart
+		}
+		database.DB.Model(&existingLeave).Updates(leave)
+		if err := updateLeaveInAssignment(leave.Username, startDate); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Leave updated successfully"})
+	}
+}
+
+// DeleteLeave handles deleting a leave by ID
+//
+//	@Summary		Delete a leave by ID
+//	@Description	Delete an existing leave; the user's assignments are rescheduled
+//	@Tags			Leave Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string	true	"API Key"
+//
+//	@Param			id		path		int		true	"Leave ID"
+//	@Success		200		{object}	string	"Leave deleted successfully"
+//	@Failure		400		{object}	string	"Invalid request payload"
+//	@Failure		404		{object}	string	"Leave not found"
+//	@Failure		500		{object}	string	"Failed to reschedule assignments"
+//	@Router			/api/v2/leave/{id} [delete]
+func DeleteLeave() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var leave models.Leave
+		database.DB.Where("id=?", b.ID).First(&leave)
+		if leave.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Leave not found"})
+		}
+		database.DB.Delete(&leave)
+		startDate, _, _ := leaveDates(&leave)
+		if err := updateLeaveInAssignment(leave.Username, startDate); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Leave deleted successfully",
+		})
+	}
+}
+
+// DisplayAllLeaves handles retrieving all leaves
+//
+//	@Summary		Get all leaves
+//	@Description	Retrieve all leaves, optionally for one user
+//	@Tags			Leave Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string			true	"API Key"
+//
+//	@Param			username	query		string			false	"Only list leaves of this user"
+//	@Success		200			{object}	models.Leave	"Leaves retrieved successfully"
+//	@Router			/api/v2/leave [get]
+func DisplayAllLeaves() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var leaves []models.Leave
+		query := database.DB
+		if username := c.Query("username"); username != "" {
+			query = query.Where("username=?", username)
+		}
+		query.Find(&leaves)
+		return c.Status(fiber.StatusOK).JSON(leaves)
+	}
+}
+
+// leaveDates parses and validates the inclusive date range of a leave and
+// rewrites its dates as ISO days
+func leaveDates(leave *models.Leave) (start, end time.Time, err error) {
+	if start, err = dates.ParseDay(leave.StartDate); err != nil {
+		return
+	}
+	if end, err = dates.ParseDay(leave.EndDate); err != nil {
+		return
+	}
+	if end.Before(start) {
+		err = errors.New("end date must not be before start date")
+	}
+	leave.StartDate, leave.EndDate = start.Format(dates.DayLayout), end.Format(dates.DayLayout)
+	return
+}
+
+// overlapsLeave reports whether another leave of the same user overlaps
+func overlapsLeave(leave models.Leave) bool {
+	var existing models.Leave
+	database.DB.Where("username=? AND id<>? AND start_date<=? AND end_date>=?", leave.Username, leave.ID, leave.EndDate, leave.StartDate).First(&existing)
+	return existing.ID != 0
+}
+
+// updateLeaveInAssignment recomputes the user's assignments that end on or
+// after from, the only ones a leave starting at from can move; shares of
+// other users keep their dates
+func updateLeaveInAssignment(username string, from time.Time) error {
+	taskAssignment.InvalidateLeaveCache()
+	var taskAssignments []models.TaskAssignment
+	database.DB.Where("username=?", username).Find(&taskAssignments)
+	var affected []models.TaskAssignment
+	for _, assignment := range taskAssignments {
+		endDate := taskAssignment.LocalAssignment(assignment).End_Date
+		if endDate.Format("2006-01-02") < from.Format("2006-01-02") {
+			continue
+		}
+		affected = append(affected, assignment)
+	}
+	_, err := taskAssignment.RecomputeAssignments(affected)
+	return err
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar day kept as YYYY-MM-DD and stored in a date column
+type Date string
+
+// Scan reads a date column, which the driver returns as a time at midnight
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = ""
+	case time.Time:
+		*d = Date(v.Format("2006-01-02"))
+	case string:
+		*d = Date(v)
+	case []byte:
+		*d = Date(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Date", value)
+	}
+	return nil
+}
+
+// Value writes the day as YYYY-MM-DD
+func (d Date) Value() (driver.Value, error) {
+	if d == "" {
+		return nil, nil
+	}
+	return string(d), nil
+}
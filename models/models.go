@@ -1,24 +1,34 @@
 package models
 
+import "time"
+
 type Task struct {
-	ID             uint   `gorm:"primaryKey" json:"id"`
-	Title          string `gorm:"not null" json:"title"`
-	Status         string `gorm:"not null" json:"status"`
-	EstimatedHours int    `gorm:"not null" json:"estimatedHours"`
+	ID               uint   `gorm:"primaryKey" json:"id"`
+	Title            string `gorm:"not null" json:"title"`
+	Status           string `gorm:"not null" json:"status"`
+	EstimatedHours   int    `gorm:"not null" json:"estimatedHours"`
+	EstimatedMinutes int    `gorm:"not null;default:0" json:"estimatedMinutes"`
 }
 
 type TaskAssignment struct {
-	ID         uint   `gorm:"primaryKey" json:"id"`
-	Username   string `gorm:"not null" json:"username"`
-	TaskID     uint   `gorm:"not null" json:"taskid"`
-	Start_Date string `gorm:"not null" json:"startDate"`
-	End_Date   string `json:"endDate"`
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Username   string    `gorm:"not null" json:"username"`
+	TaskID     uint      `gorm:"not null" json:"taskid"`
+	Start_Date time.Time `gorm:"type:timestamptz;not null;index" json:"startDate"`
+	End_Date   time.Time `gorm:"type:timestamptz;index" json:"endDate"`
+	Timezone   string    `json:"timezone"`
+	Hours      int       `json:"hours"`
+	Minutes    int       `gorm:"not null;default:0" json:"minutes"`
 }
 
 type Holiday struct {
 	ID          uint   `gorm:"primaryKey" json:"id"`
 	HolidayName string `gorm:"not null" json:"holidayName"`
-	HolidayDate string `gorm:"not null" json:"holidayDate"`
+	HolidayDate Date   `gorm:"type:date;not null;index" json:"holidayDate"`
+	StartTime   string `json:"startTime"`
+	EndTime     string `json:"endTime"`
+	CalendarID  uint   `json:"calendarID"`
+	RuleID      uint   `gorm:"-" json:"ruleID,omitempty"`
 }
 
 type User struct {
@@ -27,3 +37,64 @@ type User struct {
 	Email    string `gorm:"not null" json:"email"`
 	Password string `gorm:"not null" json:"password"`
 }
+
+type WorkingCalendar struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"not null" json:"name"`
+	DayStart    string `gorm:"not null" json:"dayStart"`
+	DayEnd      string `gorm:"not null" json:"dayEnd"`
+	Breaks      string `json:"breaks"`
+	WeekendDays string `json:"weekendDays"`
+	IsDefault   bool   `json:"isDefault"`
+}
+
+type UserSchedule struct {
+	Username    string `gorm:"primaryKey" json:"username"`
+	WorkingDays string `json:"workingDays"`
+	HoursPerDay int    `json:"hoursPerDay"`
+	Timezone    string `json:"timezone"`
+	CalendarID  uint   `json:"holidayCalendarID"`
+}
+
+type HolidayRule struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	HolidayName string `gorm:"not null" json:"holidayName"`
+	RuleType    string `gorm:"not null" json:"ruleType"`
+	Month       int    `json:"month"`
+	Day         int    `json:"day"`
+	Weekday     string `json:"weekday"`
+	Nth         int    `json:"nth"`
+	Offset      int    `json:"offset"`
+	StartTime   string `json:"startTime"`
+	EndTime     string `json:"endTime"`
+	CalendarID  uint   `json:"calendarID"`
+}
+
+type Leave struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	Username  string `gorm:"not null;index" json:"username"`
+	StartDate string `gorm:"not null" json:"startDate"`
+	EndDate   string `gorm:"not null" json:"endDate"`
+	Reason    string `json:"reason"`
+}
+
+type HolidayCalendar struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"not null;uniqueIndex" json:"name"`
+}
+
+type TaskDependency struct {
+	ID          uint `gorm:"primaryKey" json:"id"`
+	TaskID      uint `gorm:"not null;index" json:"taskid"`
+	DependsOnID uint `gorm:"not null;index" json:"dependsOnID"`
+}
+
+type TimeEntry struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Username     string     `gorm:"not null;index" json:"username"`
+	AssignmentID uint       `gorm:"not null;index" json:"assignmentID"`
+	TaskID       uint       `gorm:"not null;index" json:"taskid"`
+	StartTime    time.Time  `gorm:"type:timestamptz;not null;index" json:"startTime"`
+	EndTime      *time.Time `gorm:"type:timestamptz;index" json:"endTime"`
+	Note         string     `json:"note"`
+}
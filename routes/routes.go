@@ -3,9 +3,12 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/saran-crayonte/task/holiday"
+	"github.com/saran-crayonte/task/leave"
 	"github.com/saran-crayonte/task/task"
 	"github.com/saran-crayonte/task/taskAssignment"
+	"github.com/saran-crayonte/task/timeEntry"
 	"github.com/saran-crayonte/task/user"
+	"github.com/saran-crayonte/task/workingCalendar"
 )
 
 // SetupRoutes configures the API routes for the application
@@ -23,10 +26,16 @@ func SetupRoutes(app *fiber.App) {
 	api.Get("/refreshToken", user.RefreshToken())
 	api.Put("/user", user.UpdatePassword())
 	api.Delete("/user", user.DeleteUser())
+	api.Put("/user/schedule", user.SetSchedule())
+	api.Get("/user/schedule", user.GetSchedule())
+	api.Delete("/user/schedule", user.DeleteSchedule())
 
 	// Task routes
 	api.Post("/task", task.CreateTasks())
 	api.Get("/task", task.DisplayAllTasks())
+	api.Post("/task/dependency", task.CreateDependency())
+	api.Get("/task/dependency", task.DisplayDependencies())
+	api.Delete("/task/dependency/:id", task.DeleteDependency())
 	api.Get("/task/:id", task.GetTasks())
 	api.Put("/task/:id", task.UpdateTasks())
 	api.Delete("/task/:id", task.DeleteTasks())
@@ -34,6 +43,12 @@ func SetupRoutes(app *fiber.App) {
 	// Task assignment routes
 	api.Post("/taskAssignment", taskAssignment.CreateTaskAssignment())
 	api.Get("/taskAssignment", taskAssignment.DisplayAllTaskAssignments())
+	api.Post("/taskAssignment/autoSchedule", taskAssignment.AutoSchedule())
+	api.Post("/taskAssignment/latestStart", taskAssignment.LatestStart())
+	api.Post("/taskAssignment/simulate", taskAssignment.Simulate())
+	api.Post("/taskAssignment/workingHours", taskAssignment.WorkingHours())
+	api.Get("/taskAssignment/criticalPath", taskAssignment.CriticalPath())
+	api.Get("/taskAssignment/gantt", taskAssignment.Gantt())
 	api.Get("/taskAssignment/:id", taskAssignment.GetTaskAssignment())
 	api.Put("/taskAssignment/:id", taskAssignment.UpdateTaskAssignment())
 	api.Delete("/taskAssignment/:id", taskAssignment.DeleteTaskAssignment())
@@ -41,7 +56,47 @@ func SetupRoutes(app *fiber.App) {
 	// Holiday routes
 	api.Post("/holiday", holiday.CreateHoliday())
 	api.Get("/holiday", holiday.DisplayAllHolidays())
+	api.Post("/holiday/import", holiday.ImportHolidays())
+	api.Get("/holiday/export", holiday.ExportHolidays())
 	api.Get("/holiday/:id", holiday.GetHoliday())
 	api.Put("/holiday/:id", holiday.UpdateHoliday())
 	api.Delete("/holiday/:id", holiday.DeleteHoliday())
+
+	// Recurring holiday rule routes
+	api.Post("/holidayRule", holiday.CreateHolidayRule())
+	api.Get("/holidayRule", holiday.DisplayAllHolidayRules())
+	api.Get("/holidayRule/:id", holiday.GetHolidayRule())
+	api.Put("/holidayRule/:id", holiday.UpdateHolidayRule())
+	api.Delete("/holidayRule/:id", holiday.DeleteHolidayRule())
+
+	// Holiday calendar routes
+	api.Post("/holidayCalendar", holiday.CreateHolidayCalendar())
+	api.Get("/holidayCalendar", holiday.DisplayAllHolidayCalendars())
+	api.Put("/holidayCalendar/:id", holiday.UpdateHolidayCalendar())
+	api.Delete("/holidayCalendar/:id", holiday.DeleteHolidayCalendar())
+
+	// Leave routes
+	api.Post("/leave", leave.CreateLeave())
+	api.Get("/leave", leave.DisplayAllLeaves())
+	api.Get("/leave/:id", leave.GetLeave())
+	api.Put("/leave/:id", leave.UpdateLeave())
+	api.Delete("/leave/:id", leave.DeleteLeave())
+
+	// Time tracking routes
+	api.Post("/timeEntry", timeEntry.CreateTimeEntry())
+	api.Get("/timeEntry", timeEntry.DisplayAllTimeEntries())
+	api.Post("/timeEntry/start", timeEntry.StartTimer())
+	api.Post("/timeEntry/stop", timeEntry.StopTimer())
+	api.Get("/timeEntry/totals", timeEntry.TaskTotals())
+	api.Get("/timeEntry/timesheet", timeEntry.WeeklyTimesheet())
+	api.Get("/timeEntry/:id", timeEntry.GetTimeEntry())
+	api.Put("/timeEntry/:id", timeEntry.UpdateTimeEntry())
+	api.Delete("/timeEntry/:id", timeEntry.DeleteTimeEntry())
+
+	// Working calendar routes
+	api.Post("/workingCalendar", workingCalendar.CreateWorkingCalendar())
+	api.Get("/workingCalendar", workingCalendar.DisplayAllWorkingCalendars())
+	api.Get("/workingCalendar/:id", workingCalendar.GetWorkingCalendar())
+	api.Put("/workingCalendar/:id", workingCalendar.UpdateWorkingCalendar())
+	api.Delete("/workingCalendar/:id", workingCalendar.DeleteWorkingCalendar())
 }
@@ -0,0 +1,119 @@
+package task
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+)
+
+// CreateDependency handles adding a finish-to-start dependency between tasks
+//
+//	@Summary		Create a task dependency
+//	@Description	Make a task wait for another one: taskid cannot start until dependsOnID is done. The dependent assignment is moved to start after its predecessor ends, and later again whenever the predecessor comes to end later; it is never moved earlier.
+//	@Tags			Task Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string					true	"API Key"
+//
+//	@Param			dependency	body		models.TaskDependency	true	"Dependency details"
+//	@Success		201			{object}	models.TaskDependency	"Dependency created successfully"
+//	@Failure		400			{object}	string					"Invalid request payload / Dependency would create a cycle"
+//	@Failure		404			{object}	string					"Task not found"
+//	@Failure		409			{object}	string					"Dependency already exists"
+//	@Router			/api/v2/task/dependency [post]
+func CreateDependency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		dependency := new(models.TaskDependency)
+		if err := json.Unmarshal(c.Body(), &dependency); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		for _, id := range []uint{dependency.TaskID, dependency.DependsOnID} {
+			var existingTask models.Task
+			database.DB.Where("id = ?", id).First(&existingTask)
+			if existingTask.ID == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Task not found"})
+			}
+		}
+		if dependency.TaskID == dependency.DependsOnID || taskAssignment.DependsOn(dependency.DependsOnID, dependency.TaskID) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Dependency would create a cycle"})
+		}
+		var existingDependency models.TaskDependency
+		database.DB.Where("task_id=? AND depends_on_id=?", dependency.TaskID, dependency.DependsOnID).First(&existingDependency)
+		if existingDependency.ID != 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Dependency already exists"})
+		}
+
+		dependency.ID = 0
+		database.DB.Create(&dependency)
+		taskAssignment.PropagateDependencies(dependency.DependsOnID)
+		return c.Status(fiber.StatusCreated).JSON(dependency)
+	}
+}
+
+// DisplayDependencies handles retrieving task dependencies
+//
+//	@Summary		Get task dependencies
+//	@Description	Retrieve all task dependencies, or those of one task
+//	@Tags			Task Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string					true	"API Key"
+//
+//	@Param			taskid	query		int						false	"Only list dependencies of this task"
+//	@Success		200		{object}	models.TaskDependency	"Dependencies retrieved successfully"
+//	@Router			/api/v2/task/dependency [get]
+func DisplayDependencies() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var dependencies []models.TaskDependency
+		query := database.DB
+		if taskID := c.QueryInt("taskid"); taskID != 0 {
+			query = query.Where("task_id=?", taskID)
+		}
+		query.Find(&dependencies)
+		return c.Status(fiber.StatusOK).JSON(dependencies)
+	}
+}
+
+// DeleteDependency handles removing a task dependency by ID
+//
+//	@Summary		Delete a task dependency by ID
+//	@Description	Remove a dependency; assignments that were moved for it keep their dates
+//	@Tags			Task Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string	true	"API Key"
+//
+//	@Param			id		path		int		true	"Dependency ID"
+//	@Success		200		{object}	string	"Dependency deleted successfully"
+//	@Failure		400		{object}	string	"Invalid request payload"
+//	@Failure		404		{object}	string	"Dependency not found"
+//	@Router			/api/v2/task/dependency/{id} [delete]
+func DeleteDependency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var dependency models.TaskDependency
+		database.DB.Where("id = ?", b.ID).First(&dependency)
+		if dependency.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dependency not found"})
+		}
+		database.DB.Delete(&dependency)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Dependency deleted successfully",
+		})
+	}
+}
@@ -13,7 +13,7 @@ import (
 // CreateTasks handles creating a new task
 //
 //	@Summary		Create a new task
-//	@Description	Create a new task with provided details
+//	@Description	Create a new task with provided details; the estimate is given in estimatedMinutes, or in whole estimatedHours by older clients, and estimatedHours is stored rounded up
 //	@Tags			Task Management
 //	@Accept			json
 //	@Produce		json
@@ -23,7 +23,7 @@ import (
 //
 //	@Param			task	body		models.Task	true	"Task details"
 //	@Success		201		{object}	string		"Task created successfully"
-//	@Failure		400		{object}	string		"Invalid request payload"
+//	@Failure		400		{object}	string		"Invalid request payload / estimate must not be negative"
 //	@Router			/api/v2/task [post]
 func CreateTasks() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -38,20 +38,25 @@ func CreateTasks() fiber.Handler {
 		// 	return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Task with the same title already exists"})
 		// }
 
+		if !normalizeEstimate(task) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "estimate must not be negative"})
+		}
 		database.DB.Create(&task)
 		type UserResponse struct {
-			Message        string `json:"message"`
-			TaskID         string `json:"taskID"`
-			Title          string `json:"title"`
-			Status         string `json:"status"`
-			EstimatedHours string `json:"estimatedHours"`
+			Message          string `json:"message"`
+			TaskID           string `json:"taskID"`
+			Title            string `json:"title"`
+			Status           string `json:"status"`
+			EstimatedHours   string `json:"estimatedHours"`
+			EstimatedMinutes int    `json:"estimatedMinutes"`
 		}
 		return c.Status(fiber.StatusCreated).JSON(UserResponse{
-			Message:        "Task Created successfully",
-			TaskID:         string(rune(task.ID)),
-			Title:          task.Title,
-			Status:         task.Status,
-			EstimatedHours: string(rune(task.EstimatedHours)),
+			Message:          "Task Created successfully",
+			TaskID:           string(rune(task.ID)),
+			Title:            task.Title,
+			Status:           task.Status,
+			EstimatedHours:   string(rune(task.EstimatedHours)),
+			EstimatedMinutes: task.EstimatedMinutes,
 		})
 	}
 }
@@ -93,7 +98,7 @@ func GetTasks() fiber.Handler {
 // UpdateTasks handles updating a task by ID
 //
 //	@Summary		Update a task by ID
-//	@Description	Update an existing task by its ID
+//	@Description	Update an existing task by its ID; the estimate is given in estimatedMinutes or whole estimatedHours, and when it changes the assignees' shares are scaled with it. estimatedHours equal to the current estimate rounded up to whole hours, without estimatedMinutes, keeps the current estimate. Dependent tasks are moved later when the task comes to end later, never earlier.
 //	@Tags			Task Management
 //	@Accept			json
 //	@Produce		json
@@ -103,7 +108,7 @@ func GetTasks() fiber.Handler {
 //
 //	@Param			task	body		models.Task	true	"Updated task details"
 //	@Success		200		{object}	string		"Task updated successfully"
-//	@Failure		400		{object}	string		"Invalid request payload"
+//	@Failure		400		{object}	string		"Invalid request payload / estimate must not be negative"
 //	@Failure		404		{object}	string		"Task not found"
 //	@Router			/api/v2/task/{id} [put]
 func UpdateTasks() fiber.Handler {
@@ -124,7 +129,20 @@ func UpdateTasks() fiber.Handler {
 		// if existingTask.ID != 0 {
 		// 	return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Task with the same title already exists"})
 		// }
-		UpdatesInTaskAssignment(task.ID, task.EstimatedHours)
+		estimate := taskAssignment.EstimateMinutes(existingTask)
+		// an integer-hour client sends back the rounded up hours it read; that
+		// is no change, not a new estimate of whole hours
+		if task.EstimatedMinutes == 0 && task.EstimatedHours == taskAssignment.WholeHours(estimate) {
+			task.EstimatedHours = 0
+		}
+		if !normalizeEstimate(task) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "estimate must not be negative"})
+		}
+		if task.EstimatedMinutes != 0 {
+			taskAssignment.RescaleShares(task.ID, estimate, task.EstimatedMinutes)
+			estimate = task.EstimatedMinutes
+		}
+		UpdatesInTaskAssignment(task.ID, estimate)
 		database.DB.Model(&existingTask).Updates(task)
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"message": "Task updated successfully",
@@ -132,21 +150,39 @@ func UpdateTasks() fiber.Handler {
 	}
 }
 
+// normalizeEstimate stores the estimate of a task given in minutes or whole
+// hours as both, with the hours rounded up; it reports false for a negative
+// estimate
+func normalizeEstimate(task *models.Task) bool {
+	minutes := taskAssignment.RequestedMinutes(task.EstimatedHours, task.EstimatedMinutes)
+	if minutes < 0 {
+		return false
+	}
+	task.EstimatedMinutes, task.EstimatedHours = minutes, taskAssignment.WholeHours(minutes)
+	return true
+}
+
+// UpdatesInTaskAssignment recomputes the end date of every share of a task
+// from its start date; est is the task's estimate in minutes, which shares
+// stored without their own part carry whole
 func UpdatesInTaskAssignment(id uint, est int) {
-	taskAssign := new(models.TaskAssignment)
-	database.DB.Where("task_id=?", id).First(&taskAssign)
-	if taskAssign.ID != 0 {
-		startDate, _ := time.Parse("2006-01-02 3:04 PM", taskAssign.Start_Date)
-		result := taskAssignment.CalculateEndDate(startDate, est)
+	var taskAssigns []models.TaskAssignment
+	database.DB.Where("task_id=?", id).Find(&taskAssigns)
+	for _, taskAssign := range taskAssigns {
+		startDate := taskAssignment.LocalAssignment(taskAssign).Start_Date
+		result := taskAssignment.CalculateUserEndDate(taskAssign.Username, startDate, time.Duration(taskAssignment.ShareMinutes(taskAssign, est))*time.Minute)
 		newAssignment := models.TaskAssignment{
 			ID:         taskAssign.ID,
 			Username:   taskAssign.Username,
 			TaskID:     taskAssign.TaskID,
-			Start_Date: taskAssign.Start_Date,
-			End_Date:   result.Format("2006-01-02 3:04 PM"),
+			Start_Date: startDate,
+			End_Date:   result,
 		}
 		database.DB.Model(&taskAssign).Updates(newAssignment)
 	}
+	if len(taskAssigns) > 0 {
+		taskAssignment.PropagateDependencies(id)
+	}
 }
 
 // DeleteTasks handles deleting a task by ID
@@ -190,6 +226,8 @@ func DeleteTasks() fiber.Handler {
 func deleteInTaskAssignment(ID int) {
 	taskAssignment := new(models.TaskAssignment)
 	database.DB.Where("task_id=?", ID).Delete(&taskAssignment)
+	database.DB.Where("task_id=? OR depends_on_id=?", ID, ID).Delete(&models.TaskDependency{})
+	database.DB.Where("task_id=?", ID).Delete(&models.TimeEntry{})
 }
 
 // DisplayAllTasks handles retrieving all tasks
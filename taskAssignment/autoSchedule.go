@@ -0,0 +1,303 @@
+package taskAssignment
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// AutoScheduleRequest lists the tasks to place and the users that may take them
+type AutoScheduleRequest struct {
+	TaskIDs   []uint   `json:"taskIDs"`
+	Usernames []string `json:"usernames"`
+	StartDate string   `json:"startDate"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+// PlannedAssignment is one assignment proposed by the auto scheduler;
+// EstimatedMinutes is the part of the task not yet shared out, and
+// EstimatedHours the same rounded up to whole hours
+type PlannedAssignment struct {
+	ID               uint   `json:"id,omitempty"`
+	TaskID           uint   `json:"taskid"`
+	Title            string `json:"title"`
+	EstimatedHours   int    `json:"estimatedHours"`
+	EstimatedMinutes int    `json:"estimatedMinutes"`
+	Username         string `json:"username"`
+	StartDate        string `json:"startDate"`
+	EndDate          string `json:"endDate"`
+	start, end       time.Time
+}
+
+// SkippedTask is a requested task the auto scheduler did not place
+type SkippedTask struct {
+	TaskID uint   `json:"taskid"`
+	Reason string `json:"reason"`
+}
+
+// AutoScheduleResult is the plan produced by the auto scheduler
+type AutoScheduleResult struct {
+	DryRun      bool                `json:"dryRun"`
+	Assignments []PlannedAssignment `json:"assignments"`
+	Skipped     []SkippedTask       `json:"skipped"`
+	UserHours   map[string]int      `json:"userHours"`
+	UserMinutes map[string]int      `json:"userMinutes"`
+	FinishDate  string              `json:"finishDate"`
+}
+
+// AutoSchedule handles assigning unassigned tasks across candidate users
+//
+//	@Summary		Auto schedule unassigned tasks
+//	@Description	Assign tasks (all tasks with hours not yet shared out when taskIDs is empty) to the candidate users; a partly assigned task is planned for its remaining hours, on a candidate who holds no share of it yet. Tasks are placed in dependency order, longest first among those whose predecessors are placed, each on the user who would finish it earliest after their existing work, which balances load and keeps the overall finish date early; no task starts before its predecessors end. With dryRun the plan is returned without creating assignments.
+//	@Tags			Task Assignment
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			request		body		AutoScheduleRequest	true	"Tasks, candidate users, earliest start date and dry run flag"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	AutoScheduleResult	"Planned assignments (dry run)"
+//	@Success		201			{object}	AutoScheduleResult	"Assignments created"
+//	@Failure		400			{object}	string				"Invalid request payload / invalid date time format"
+//	@Failure		404			{object}	string				"Username doesn't exist"
+//	@Router			/api/v2/taskAssignment/autoSchedule [post]
+func AutoSchedule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := new(AutoScheduleRequest)
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if len(request.Usernames) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "At least one username is required"})
+		}
+		for _, username := range request.Usernames {
+			var existingUser models.User
+			database.DB.Where("username=?", username).First(&existingUser)
+			if len(existingUser.Username) == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Username doesn't exists", "username": username})
+			}
+		}
+
+		// only the part of a task not yet shared out is planned
+		var tasks []models.Task
+		var skipped []SkippedTask
+		if len(request.TaskIDs) == 0 {
+			var allTasks []models.Task
+			database.DB.Find(&allTasks)
+			for _, existingTask := range allTasks {
+				if remaining := UnassignedMinutes(existingTask, 0); remaining > 0 {
+					existingTask.EstimatedMinutes = remaining
+					tasks = append(tasks, existingTask)
+				}
+			}
+		} else {
+			requested := make(map[uint]bool)
+			for _, id := range request.TaskIDs {
+				if requested[id] {
+					continue
+				}
+				requested[id] = true
+				var existingTask models.Task
+				database.DB.Where("id=?", id).First(&existingTask)
+				if existingTask.ID == 0 {
+					skipped = append(skipped, SkippedTask{TaskID: id, Reason: "Task not found"})
+					continue
+				}
+				remaining := UnassignedMinutes(existingTask, 0)
+				if remaining == 0 {
+					skipped = append(skipped, SkippedTask{TaskID: id, Reason: "Task is already fully assigned"})
+					continue
+				}
+				existingTask.EstimatedMinutes = remaining
+				tasks = append(tasks, existingTask)
+			}
+		}
+
+		plan, err := planAssignments(tasks, request.Usernames, request.StartDate, dates.ResponseFormat(c))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+		}
+		plan.DryRun = request.DryRun
+		plan.Skipped = append(skipped, plan.Skipped...)
+		if request.DryRun {
+			return c.Status(fiber.StatusOK).JSON(plan)
+		}
+
+		err = database.DB.Transaction(func(tx *gorm.DB) error {
+			for i, planned := range plan.Assignments {
+				assignment := models.TaskAssignment{
+					Username:   planned.Username,
+					TaskID:     planned.TaskID,
+					Start_Date: planned.start,
+					End_Date:   planned.end,
+					Hours:      planned.EstimatedHours,
+					Minutes:    planned.EstimatedMinutes,
+				}
+				if err := tx.Create(&assignment).Error; err != nil {
+					return err
+				}
+				plan.Assignments[i].ID = assignment.ID
+			}
+			// dependents that were already assigned move after the new shares
+			for _, planned := range plan.Assignments {
+				if _, err := propagate(tx, nil, planned.TaskID, make(map[uint]bool)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create assignments"})
+		}
+		return c.Status(fiber.StatusCreated).JSON(plan)
+	}
+}
+
+// planAssignments places the tasks in dependency order, longest first among
+// those whose predecessors are placed, each on the user that would finish it
+// earliest given their existing and already planned work; no task starts
+// before its planned or assigned predecessors end, and a user who already
+// holds a share of a task is not given another
+func planAssignments(tasks []models.Task, usernames []string, startDate, format string) (AutoScheduleResult, error) {
+	result := AutoScheduleResult{UserHours: make(map[string]int), UserMinutes: make(map[string]int)}
+	available := make(map[string]time.Time)
+	for _, username := range usernames {
+		loc := UserLocation(username)
+		start := time.Now().In(loc).Truncate(time.Minute)
+		if startDate != "" {
+			var err error
+			if start, err = dates.Parse(startDate, loc); err != nil {
+				return result, err
+			}
+		}
+		available[username] = QueueStart(username, 0, start).In(loc)
+		result.UserMinutes[username] = 0
+	}
+
+	var dependencies []models.TaskDependency
+	database.DB.Find(&dependencies)
+	tasks = dependencyOrder(tasks, dependencies)
+
+	holders := make(map[uint]map[string]bool)
+	if len(tasks) > 0 {
+		taskIDs := make([]uint, 0, len(tasks))
+		for _, task := range tasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+		var assigned []models.TaskAssignment
+		database.DB.Where("task_id IN ?", taskIDs).Find(&assigned)
+		for _, assignment := range assigned {
+			if holders[assignment.TaskID] == nil {
+				holders[assignment.TaskID] = make(map[string]bool)
+			}
+			holders[assignment.TaskID][assignment.Username] = true
+		}
+	}
+
+	plannedEnd := make(map[uint]time.Time)
+	var finish time.Time
+	for _, task := range tasks {
+		minutes := EstimateMinutes(task)
+		var best string
+		var bestStart, bestEnd time.Time
+		for _, username := range usernames {
+			if holders[task.ID][username] {
+				continue
+			}
+			start := available[username]
+			earliest := DependencyStart(task.ID, start)
+			for _, dependency := range dependencies {
+				if end, ok := plannedEnd[dependency.DependsOnID]; ok && dependency.TaskID == task.ID && end.After(earliest) {
+					earliest = end
+				}
+			}
+			if earliest.After(start) {
+				start = earliest.In(start.Location())
+			}
+			end := CalculateUserEndDate(username, start, time.Duration(minutes)*time.Minute)
+			if best == "" || end.Before(bestEnd) || (end.Equal(bestEnd) && result.UserMinutes[username] < result.UserMinutes[best]) {
+				best, bestStart, bestEnd = username, start, end
+			}
+		}
+		if best == "" {
+			result.Skipped = append(result.Skipped, SkippedTask{TaskID: task.ID, Reason: "Every candidate already holds a share of the task"})
+			continue
+		}
+		result.Assignments = append(result.Assignments, PlannedAssignment{
+			TaskID:           task.ID,
+			Title:            task.Title,
+			EstimatedHours:   WholeHours(minutes),
+			EstimatedMinutes: minutes,
+			Username:         best,
+			StartDate:        dates.Format(bestStart, format),
+			EndDate:          dates.Format(bestEnd, format),
+			start:            bestStart,
+			end:              bestEnd,
+		})
+		available[best] = bestEnd
+		if bestEnd.After(plannedEnd[task.ID]) {
+			plannedEnd[task.ID] = bestEnd
+		}
+		result.UserMinutes[best] += minutes
+		if bestEnd.After(finish) {
+			finish = bestEnd
+		}
+	}
+	for username, minutes := range result.UserMinutes {
+		result.UserHours[username] = WholeHours(minutes)
+	}
+	if !finish.IsZero() {
+		result.FinishDate = dates.Format(finish, format)
+	}
+	return result, nil
+}
+
+// dependencyOrder sorts the tasks so each comes after the tasks it depends
+// on, choosing the longest among those ready; tasks caught in a cycle of
+// stored dependencies follow, longest first
+func dependencyOrder(tasks []models.Task, dependencies []models.TaskDependency) []models.Task {
+	pending := make(map[uint]int)
+	for _, task := range tasks {
+		pending[task.ID] = 0
+	}
+	dependents := make(map[uint][]uint)
+	for _, dependency := range dependencies {
+		_, isTask := pending[dependency.TaskID]
+		_, isPredecessor := pending[dependency.DependsOnID]
+		if isTask && isPredecessor {
+			pending[dependency.TaskID]++
+			dependents[dependency.DependsOnID] = append(dependents[dependency.DependsOnID], dependency.TaskID)
+		}
+	}
+
+	remaining := append([]models.Task(nil), tasks...)
+	sort.SliceStable(remaining, func(i, j int) bool { return EstimateMinutes(remaining[i]) > EstimateMinutes(remaining[j]) })
+	ordered := make([]models.Task, 0, len(tasks))
+	for len(remaining) > 0 {
+		next := -1
+		for i, task := range remaining {
+			if pending[task.ID] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return append(ordered, remaining...)
+		}
+		task := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+		ordered = append(ordered, task)
+		for _, dependent := range dependents[task.ID] {
+			pending[dependent]--
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,37 @@
+package taskAssignment
+
+import (
+	"testing"
+
+	"github.com/saran-crayonte/task/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyOrder(t *testing.T) {
+	ids := func(tasks []models.Task) []uint {
+		var result []uint
+		for _, task := range tasks {
+			result = append(result, task.ID)
+		}
+		return result
+	}
+	tasks := []models.Task{
+		{ID: 1, EstimatedMinutes: 60},
+		{ID: 2, EstimatedMinutes: 600},
+		{ID: 3, EstimatedMinutes: 300},
+		{ID: 4, EstimatedMinutes: 120},
+	}
+	assert.Equal(t, []uint{2, 3, 4, 1}, ids(dependencyOrder(tasks, nil)), "longest first without dependencies")
+
+	dependencies := []models.TaskDependency{
+		{TaskID: 2, DependsOnID: 1},
+		{TaskID: 3, DependsOnID: 2},
+		// predecessors outside the plan do not hold a task back
+		{TaskID: 4, DependsOnID: 9},
+	}
+	assert.Equal(t, []uint{4, 1, 2, 3}, ids(dependencyOrder(tasks, dependencies)))
+
+	cycle := append(dependencies, models.TaskDependency{TaskID: 1, DependsOnID: 3})
+	assert.Equal(t, []uint{4, 2, 3, 1}, ids(dependencyOrder(tasks, cycle)), "a stored cycle falls back to longest first")
+	assert.Equal(t, []uint{1, 2, 3, 4}, ids(tasks), "the input is left alone")
+}
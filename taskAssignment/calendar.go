@@ -0,0 +1,350 @@
+package taskAssignment
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// span is a part of a day expressed as offsets from midnight
+type span struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// WorkingCalendar is the parsed form of models.WorkingCalendar used for scheduling
+type WorkingCalendar struct {
+	windows           []span
+	weekend           map[time.Weekday]bool
+	holidayCalendarID uint
+	username          string
+	// holidays replaces the shared holiday calendar, as in a simulation
+	holidays *holidayIndex
+}
+
+// DefaultWorkingCalendar is used when no calendar has been marked as default
+var DefaultWorkingCalendar = models.WorkingCalendar{
+	Name:        "Default",
+	DayStart:    "09:00",
+	DayEnd:      "18:00",
+	Breaks:      "12:00-13:00",
+	WeekendDays: "Saturday,Sunday",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.New("invalid time format, expected HH:MM")
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseWeekdays parses a comma separated list of weekday names
+func ParseWeekdays(value string) (map[time.Weekday]bool, error) {
+	days := make(map[time.Weekday]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, errors.New("invalid weekday " + name)
+		}
+		days[day] = true
+	}
+	return days, nil
+}
+
+// ParseHolidayHours validates the optional closing hours of a holiday and
+// returns the closed part of the day; a holiday without hours closes all day
+func ParseHolidayHours(holiday models.Holiday) (start, end time.Duration, err error) {
+	if holiday.StartTime == "" && holiday.EndTime == "" {
+		return 0, 24 * time.Hour, nil
+	}
+	start, end = 0, 24*time.Hour
+	if holiday.StartTime != "" {
+		if start, err = parseClock(holiday.StartTime); err != nil {
+			return 0, 0, err
+		}
+	}
+	if holiday.EndTime != "" {
+		if end, err = parseClock(holiday.EndTime); err != nil {
+			return 0, 0, err
+		}
+	}
+	if end <= start {
+		return 0, 0, errors.New("holiday end time must be after start time")
+	}
+	return start, end, nil
+}
+
+// ParseWorkingCalendar validates a stored calendar and converts it into working windows
+func ParseWorkingCalendar(calendar models.WorkingCalendar) (*WorkingCalendar, error) {
+	dayStart, err := parseClock(calendar.DayStart)
+	if err != nil {
+		return nil, err
+	}
+	dayEnd, err := parseClock(calendar.DayEnd)
+	if err != nil {
+		return nil, err
+	}
+	if dayEnd <= dayStart {
+		return nil, errors.New("day end must be after day start")
+	}
+
+	var breaks []span
+	for _, item := range strings.Split(calendar.Breaks, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		bounds := strings.Split(item, "-")
+		if len(bounds) != 2 {
+			return nil, errors.New("invalid break, expected HH:MM-HH:MM")
+		}
+		breakStart, err := parseClock(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		breakEnd, err := parseClock(bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		if breakEnd <= breakStart || breakStart < dayStart || breakEnd > dayEnd {
+			return nil, errors.New("breaks must fall within the working day")
+		}
+		breaks = append(breaks, span{breakStart, breakEnd})
+	}
+	sort.Slice(breaks, func(i, j int) bool { return breaks[i].start < breaks[j].start })
+
+	weekend, err := ParseWeekdays(calendar.WeekendDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(weekend) == 7 {
+		return nil, errors.New("calendar must have at least one working day")
+	}
+
+	cal := &WorkingCalendar{weekend: weekend}
+	cursor := dayStart
+	for _, b := range breaks {
+		if b.start < cursor {
+			return nil, errors.New("breaks must not overlap")
+		}
+		if b.start > cursor {
+			cal.windows = append(cal.windows, span{cursor, b.start})
+		}
+		cursor = b.end
+	}
+	if cursor < dayEnd {
+		cal.windows = append(cal.windows, span{cursor, dayEnd})
+	}
+	if len(cal.windows) == 0 {
+		return nil, errors.New("breaks must leave working hours in the day")
+	}
+	return cal, nil
+}
+
+// ActiveWorkingCalendar returns the default calendar stored in the database,
+// falling back to DefaultWorkingCalendar when none is configured
+func ActiveWorkingCalendar() *WorkingCalendar {
+	return activeWorkingCalendar(database.DB)
+}
+
+func activeWorkingCalendar(db *gorm.DB) *WorkingCalendar {
+	var calendar models.WorkingCalendar
+	db.Where("is_default = ?", true).First(&calendar)
+	if calendar.ID != 0 {
+		if cal, err := ParseWorkingCalendar(calendar); err == nil {
+			return cal
+		}
+	}
+	cal, _ := ParseWorkingCalendar(DefaultWorkingCalendar)
+	return cal
+}
+
+// clock returns the wall clock time offset from midnight on the day of t, so
+// working hours stay local across daylight saving transitions
+func clock(t time.Time, offset time.Duration) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, t.Location())
+}
+
+// dayWindows returns the working windows of the day containing t, with
+// weekend days and closed holiday hours removed
+func (cal *WorkingCalendar) dayWindows(t time.Time) []span {
+	if cal.weekend[t.Weekday()] {
+		return nil
+	}
+	var closed []span
+	if cal.holidays != nil {
+		closed = cal.holidays.closures(cal.holidayCalendarID, t)
+	} else {
+		closed = holidayClosures(cal.holidayCalendarID, t)
+	}
+	windows := subtract(cal.windows, closed)
+	if cal.username != "" {
+		windows = subtract(windows, leaveClosures(cal.username, t))
+	}
+	return windows
+}
+
+// IsWeekend reports whether the day containing t is not a working day of the
+// calendar
+func (cal *WorkingCalendar) IsWeekend(t time.Time) bool {
+	return cal.weekend[t.Weekday()]
+}
+
+// Holidays returns the names of the whole and part-day holidays on the day
+// containing t in the calendar's holiday calendar
+func (cal *WorkingCalendar) Holidays(t time.Time) []string {
+	if cal.holidays != nil {
+		return cal.holidays.holidayNames(cal.holidayCalendarID, t)
+	}
+	holidayMu.Lock()
+	defer holidayMu.Unlock()
+	if holidayCache == nil {
+		holidayCache = loadHolidayIndex(database.DB)
+	}
+	return holidayCache.holidayNames(cal.holidayCalendarID, t)
+}
+
+// OnLeave reports whether the calendar's user is on leave on the day
+// containing t
+func (cal *WorkingCalendar) OnLeave(t time.Time) bool {
+	return cal.username != "" && len(leaveClosures(cal.username, t)) > 0
+}
+
+// subtract removes the closed spans from the working windows
+func subtract(windows []span, closed []span) []span {
+	for _, c := range closed {
+		var open []span
+		for _, w := range windows {
+			if c.end <= w.start || c.start >= w.end {
+				open = append(open, w)
+				continue
+			}
+			if c.start > w.start {
+				open = append(open, span{w.start, c.start})
+			}
+			if c.end < w.end {
+				open = append(open, span{c.end, w.end})
+			}
+		}
+		windows = open
+	}
+	return windows
+}
+
+// nextDayStart returns midnight of the day after t
+func (cal *WorkingCalendar) nextDayStart(t time.Time) time.Time {
+	return clock(t.AddDate(0, 0, 1), 0)
+}
+
+// workingTimeBetween returns the working time between from and to; it is
+// negative when to is before from
+func (cal *WorkingCalendar) workingTimeBetween(from, to time.Time) time.Duration {
+	if to.Before(from) {
+		return -cal.workingTimeBetween(to, from)
+	}
+	var total time.Duration
+	for day := clock(from, 0); day.Before(to); day = cal.nextDayStart(day) {
+		for _, w := range cal.dayWindows(day) {
+			windowStart, windowEnd := clock(day, w.start), clock(day, w.end)
+			if windowStart.Before(from) {
+				windowStart = from
+			}
+			if windowEnd.After(to) {
+				windowEnd = to
+			}
+			if windowEnd.After(windowStart) {
+				total += windowEnd.Sub(windowStart)
+			}
+		}
+	}
+	return total
+}
+
+// ForSchedule narrows the calendar to the days and daily hours of a user's
+// schedule and switches to the user's holiday calendar; a schedule that
+// leaves no working day or no working hours is an error
+func (cal *WorkingCalendar) ForSchedule(schedule models.UserSchedule) (*WorkingCalendar, error) {
+	if schedule.HoursPerDay < 0 || schedule.HoursPerDay > 24 {
+		return nil, errors.New("hours per day must be between 0 and 24")
+	}
+	userCal := &WorkingCalendar{windows: cal.windows, weekend: cal.weekend, holidayCalendarID: schedule.CalendarID}
+
+	if strings.TrimSpace(schedule.WorkingDays) != "" {
+		workingDays, err := ParseWeekdays(schedule.WorkingDays)
+		if err != nil {
+			return nil, err
+		}
+		weekend := make(map[time.Weekday]bool)
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if cal.weekend[day] || !workingDays[day] {
+				weekend[day] = true
+			}
+		}
+		if len(weekend) == 7 {
+			return nil, errors.New("working days must include a working day of the working calendar")
+		}
+		userCal.weekend = weekend
+	}
+
+	if schedule.HoursPerDay > 0 {
+		remaining := time.Duration(schedule.HoursPerDay) * time.Hour
+		var windows []span
+		for _, w := range cal.windows {
+			if remaining <= 0 {
+				break
+			}
+			if w.end-w.start > remaining {
+				w.end = w.start + remaining
+			}
+			remaining -= w.end - w.start
+			windows = append(windows, w)
+		}
+		userCal.windows = windows
+	}
+	if len(userCal.windows) == 0 {
+		return nil, errors.New("schedule must leave working hours in the day")
+	}
+	return userCal, nil
+}
+
+// UserWorkingCalendar returns the active calendar narrowed to the user's
+// schedule, with the user's leave treated as holidays
+func UserWorkingCalendar(username string) *WorkingCalendar {
+	return userWorkingCalendar(database.DB, nil, username)
+}
+
+// userWorkingCalendar reads the calendar and schedule through db, so a
+// transaction sees its own writes; holidays replaces the shared holiday
+// calendar when not nil
+func userWorkingCalendar(db *gorm.DB, holidays *holidayIndex, username string) *WorkingCalendar {
+	cal := activeWorkingCalendar(db)
+	var schedule models.UserSchedule
+	db.Where("username = ?", username).First(&schedule)
+	if schedule.Username != "" {
+		if userCal, err := cal.ForSchedule(schedule); err == nil {
+			cal = userCal
+		}
+	}
+	cal.username = username
+	cal.holidays = holidays
+	return cal
+}
@@ -0,0 +1,109 @@
+package taskAssignment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// Scheduling modes for a new or updated assignment
+const (
+	// ModeParallel schedules the assignment as if the user had nothing else to do
+	ModeParallel = "parallel"
+	// ModeQueue starts the assignment once the user's existing work is finished
+	ModeQueue = "queue"
+	// ModeReject refuses an assignment that overlaps the user's existing work
+	ModeReject = "reject"
+	// ModeBackward starts the assignment as late as possible to finish by its endDate
+	ModeBackward = "backward"
+)
+
+// AssignmentSpan is the scheduled period of an existing assignment
+type AssignmentSpan struct {
+	ID     uint      `json:"id"`
+	TaskID uint      `json:"taskid"`
+	Start  time.Time `json:"startDate"`
+	End    time.Time `json:"endDate"`
+}
+
+// UserAssignmentSpans returns the scheduled periods of a user's assignments,
+// leaving out excludeID
+func UserAssignmentSpans(username string, excludeID uint) []AssignmentSpan {
+	var assignments []models.TaskAssignment
+	database.DB.Where("username=? AND id<>?", username, excludeID).Find(&assignments)
+	var spans []AssignmentSpan
+	for _, assignment := range assignments {
+		assignment = LocalAssignment(assignment)
+		spans = append(spans, AssignmentSpan{ID: assignment.ID, TaskID: assignment.TaskID, Start: assignment.Start_Date, End: assignment.End_Date})
+	}
+	return spans
+}
+
+// QueueStart returns startDate, or the end of the user's last assignment when
+// that is later
+func QueueStart(username string, excludeID uint, startDate time.Time) time.Time {
+	for _, s := range UserAssignmentSpans(username, excludeID) {
+		if s.End.After(startDate) {
+			startDate = s.End
+		}
+	}
+	return startDate
+}
+
+// OverlappingAssignments returns the user's assignments that overlap [start, end)
+func OverlappingAssignments(username string, excludeID uint, start, end time.Time) []AssignmentSpan {
+	var overlapping []AssignmentSpan
+	for _, s := range UserAssignmentSpans(username, excludeID) {
+		if s.Start.Before(end) && start.Before(s.End) {
+			overlapping = append(overlapping, s)
+		}
+	}
+	return overlapping
+}
+
+// scheduleAssignment computes the start and end of an assignment in the given
+// mode, never starting before the task's predecessors end; in reject mode the
+// overlapping assignments are returned as conflicts, and in backward mode the
+// start is worked back from the assignment's end date
+func scheduleAssignment(assignment AssignmentRequest, estimate time.Duration, mode string) (start, end time.Time, conflicts []AssignmentSpan, err error) {
+	loc := AssignmentLocation(models.TaskAssignment{Username: assignment.Username, Timezone: assignment.Timezone})
+	switch mode {
+	case ModeParallel, ModeReject:
+		if start, err = dates.Parse(assignment.Start_Date, loc); err != nil {
+			return start, end, nil, dates.ErrInvalidDate
+		}
+	case ModeQueue:
+		// without a start date the assignment simply joins the user's queue
+		if assignment.Start_Date != "" {
+			if start, err = dates.Parse(assignment.Start_Date, loc); err != nil {
+				return start, end, nil, dates.ErrInvalidDate
+			}
+		}
+		start = QueueStart(assignment.Username, assignment.ID, start)
+		if start.IsZero() {
+			return start, end, nil, errors.New("start date is required when the user has no assignments")
+		}
+		start = start.In(loc)
+	case ModeBackward:
+		deadline, err := dates.Parse(assignment.End_Date, loc)
+		if err != nil {
+			return start, end, nil, dates.ErrInvalidDate
+		}
+		start = CalculateUserStartDate(assignment.Username, deadline, estimate)
+		if DependencyStart(assignment.TaskID, start).After(start) {
+			return start, end, nil, errors.New("the end date cannot be met after the task's predecessors finish")
+		}
+	default:
+		return start, end, nil, errors.New("mode must be parallel, queue, reject or backward")
+	}
+
+	start = DependencyStart(assignment.TaskID, start).In(loc)
+	end = CalculateUserEndDate(assignment.Username, start, estimate)
+	if mode == ModeReject {
+		conflicts = OverlappingAssignments(assignment.Username, assignment.ID, start, end)
+	}
+	return start, end, conflicts, nil
+}
@@ -0,0 +1,192 @@
+package taskAssignment
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// CriticalTask is an assigned task with its slack in the schedule; a shared
+// task spans from its first share's start to its last share's end, and
+// Username is the assignee of the share that ends last
+type CriticalTask struct {
+	TaskID     uint     `json:"taskid"`
+	Title      string   `json:"title"`
+	Username   string   `json:"username"`
+	Usernames  []string `json:"usernames"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate"`
+	SlackHours float64  `json:"slackHours"`
+	Critical   bool     `json:"critical"`
+}
+
+// CriticalPathResult is the outcome of a critical path analysis
+type CriticalPathResult struct {
+	FinishDate   string         `json:"finishDate"`
+	CriticalPath []CriticalTask `json:"criticalPath"`
+	Tasks        []CriticalTask `json:"tasks"`
+}
+
+// criticalNode is an assigned task in the dependency graph
+type criticalNode struct {
+	task         CriticalTask
+	start, end   time.Time
+	calendar     *WorkingCalendar
+	predecessors []uint
+	successors   []uint
+	slack        time.Duration
+}
+
+// CriticalPath handles computing the critical path of the assigned tasks
+//
+//	@Summary		Critical path analysis
+//	@Description	Compute the overall finish date, the slack of every assigned task in working hours (how long it can slip without moving the finish date) and the chain of zero-slack tasks that decides the delivery date. Uses the stored assignment dates and task dependencies.
+//	@Tags			Task Assignment
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	CriticalPathResult	"Critical path computed successfully"
+//	@Router			/api/v2/taskAssignment/criticalPath [get]
+func CriticalPath() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(analyseCriticalPath(dates.ResponseFormat(c)))
+	}
+}
+
+func analyseCriticalPath(format string) CriticalPathResult {
+	result := CriticalPathResult{CriticalPath: []CriticalTask{}, Tasks: []CriticalTask{}}
+
+	var assignments []models.TaskAssignment
+	database.DB.Find(&assignments)
+	nodes := make(map[uint]*criticalNode)
+	calendars := make(map[string]*WorkingCalendar)
+	var finish time.Time
+	for _, assignment := range assignments {
+		assignment = LocalAssignment(assignment)
+		start, end := assignment.Start_Date, assignment.End_Date
+		if calendars[assignment.Username] == nil {
+			calendars[assignment.Username] = UserWorkingCalendar(assignment.Username)
+		}
+		if node := nodes[assignment.TaskID]; node != nil {
+			node.task.Usernames = append(node.task.Usernames, assignment.Username)
+			if start.Before(node.start) {
+				node.start, node.task.StartDate = start, dates.Format(start, format)
+			}
+			if end.After(node.end) {
+				node.end, node.task.EndDate = end, dates.Format(end, format)
+				node.task.Username, node.calendar = assignment.Username, calendars[assignment.Username]
+			}
+		} else {
+			var task models.Task
+			database.DB.Where("id=?", assignment.TaskID).First(&task)
+			nodes[assignment.TaskID] = &criticalNode{
+				task: CriticalTask{
+					TaskID:    assignment.TaskID,
+					Title:     task.Title,
+					Username:  assignment.Username,
+					Usernames: []string{assignment.Username},
+					StartDate: dates.Format(start, format),
+					EndDate:   dates.Format(end, format),
+				},
+				start:    start,
+				end:      end,
+				calendar: calendars[assignment.Username],
+			}
+		}
+		if end.After(finish) {
+			finish = end
+		}
+	}
+	if len(nodes) == 0 {
+		return result
+	}
+	result.FinishDate = dates.Format(finish, format)
+
+	var dependencies []models.TaskDependency
+	database.DB.Find(&dependencies)
+	for _, dependency := range dependencies {
+		successor, predecessor := nodes[dependency.TaskID], nodes[dependency.DependsOnID]
+		if successor == nil || predecessor == nil {
+			continue
+		}
+		successor.predecessors = append(successor.predecessors, dependency.DependsOnID)
+		predecessor.successors = append(predecessor.successors, dependency.TaskID)
+	}
+
+	// backward pass: a task's slack is the smallest working time gap it can
+	// absorb on any route to the finish date
+	done := make(map[uint]bool)
+	var slackOf func(id uint) time.Duration
+	slackOf = func(id uint) time.Duration {
+		node := nodes[id]
+		if done[id] {
+			return node.slack
+		}
+		done[id] = true
+		node.slack = time.Duration(math.MaxInt64)
+		if len(node.successors) == 0 {
+			node.slack = node.calendar.workingTimeBetween(node.end, finish)
+		}
+		for _, successorID := range node.successors {
+			successorSlack := slackOf(successorID)
+			if successorSlack == time.Duration(math.MaxInt64) {
+				// only reachable through a dependency cycle in stored data
+				continue
+			}
+			slack := node.calendar.workingTimeBetween(node.end, nodes[successorID].start) + successorSlack
+			if slack < node.slack {
+				node.slack = slack
+			}
+		}
+		return node.slack
+	}
+
+	ids := make([]uint, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if !nodes[ids[i]].start.Equal(nodes[ids[j]].start) {
+			return nodes[ids[i]].start.Before(nodes[ids[j]].start)
+		}
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		node := nodes[id]
+		slackOf(id)
+		node.task.SlackHours = math.Round(node.slack.Hours()*100) / 100
+		node.task.Critical = node.slack < time.Minute
+		result.Tasks = append(result.Tasks, node.task)
+	}
+
+	// walk back from the task that finishes last through its latest-ending
+	// critical predecessors
+	var last *criticalNode
+	for _, id := range ids {
+		if node := nodes[id]; node.task.Critical && (last == nil || node.end.After(last.end)) {
+			last = node
+		}
+	}
+	var path []CriticalTask
+	for last != nil {
+		path = append([]CriticalTask{last.task}, path...)
+		var driving *criticalNode
+		for _, predecessorID := range last.predecessors {
+			if predecessor := nodes[predecessorID]; predecessor.task.Critical && (driving == nil || predecessor.end.After(driving.end)) {
+				driving = predecessor
+			}
+		}
+		last = driving
+	}
+	result.CriticalPath = path
+	return result
+}
@@ -0,0 +1,110 @@
+package taskAssignment
+
+import (
+	"time"
+
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// DependsOn reports whether taskID depends on other, directly or through
+// other dependencies
+func DependsOn(taskID, other uint) bool {
+	visited := make(map[uint]bool)
+	queue := []uint{taskID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		var dependencies []models.TaskDependency
+		database.DB.Where("task_id=?", current).Find(&dependencies)
+		for _, dependency := range dependencies {
+			if dependency.DependsOnID == other {
+				return true
+			}
+			queue = append(queue, dependency.DependsOnID)
+		}
+	}
+	return false
+}
+
+// DependencyStart returns start, or the latest end of the task's assigned
+// predecessors when that is later (finish-to-start); a shared predecessor
+// ends with its last share
+func DependencyStart(taskID uint, start time.Time) time.Time {
+	return dependencyStart(database.DB, taskID, start)
+}
+
+func dependencyStart(db *gorm.DB, taskID uint, start time.Time) time.Time {
+	var dependencies []models.TaskDependency
+	db.Where("task_id=?", taskID).Find(&dependencies)
+	for _, dependency := range dependencies {
+		_, end, ok := taskSpan(db, dependency.DependsOnID)
+		if ok && end.After(start) {
+			start = end
+		}
+	}
+	return start
+}
+
+// PropagateDependencies moves the assignments of tasks that depend on taskID
+// so they start no earlier than their predecessors end, and recomputes their
+// end dates; the change is carried on to their own dependents. Dependents
+// are only ever pushed later: when a predecessor ends earlier they keep
+// their dates, since a later start may have been chosen on purpose.
+func PropagateDependencies(taskID uint) {
+	propagate(database.DB, nil, taskID, make(map[uint]bool))
+}
+
+// propagate walks the dependents depth first and returns the number of
+// assignments it moved later; path holds the tasks on the current walk so a cycle
+// in stored data cannot recurse forever; a nil holidays uses the shared
+// holiday calendar
+func propagate(db *gorm.DB, holidays *holidayIndex, taskID uint, path map[uint]bool) (int, error) {
+	if path[taskID] {
+		return 0, nil
+	}
+	path[taskID] = true
+	defer delete(path, taskID)
+
+	moved := 0
+	var dependents []models.TaskDependency
+	db.Where("depends_on_id=?", taskID).Find(&dependents)
+	for _, dependent := range dependents {
+		var dependentTask models.Task
+		db.Where("id=?", dependent.TaskID).First(&dependentTask)
+		var assignments []models.TaskAssignment
+		db.Where("task_id=?", dependent.TaskID).Find(&assignments)
+		shares := 0
+		for _, assignment := range assignments {
+			start := assignment.Start_Date
+			earliest := dependencyStart(db, dependent.TaskID, start)
+			if !earliest.After(start) {
+				continue
+			}
+			start = earliest.In(AssignmentLocation(assignment))
+			end := userWorkingCalendar(db, holidays, assignment.Username).EndDate(start, shareDuration(assignment, dependentTask))
+			err := db.Model(&assignment).Updates(models.TaskAssignment{
+				Start_Date: start,
+				End_Date:   end,
+			}).Error
+			if err != nil {
+				return moved, err
+			}
+			shares++
+		}
+		if shares > 0 {
+			moved += shares
+			n, err := propagate(db, holidays, dependent.TaskID, path)
+			moved += n
+			if err != nil {
+				return moved, err
+			}
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,216 @@
+package taskAssignment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/saran-crayonte/task/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// useCalendarData fills the in-memory holiday and leave calendars so end
+// dates can be computed without a database
+func useCalendarData(holidays []models.Holiday, rules []models.HolidayRule, leaves []models.Leave) {
+	holidayCache = newHolidayIndex(holidays, rules)
+	leaveCache = make(leaveIndex)
+	for _, leave := range leaves {
+		leaveCache[leave.Username] = append(leaveCache[leave.Username], leave)
+	}
+}
+
+// hourlyEndDate is the original hour by hour walk the day granular EndDate
+// has to agree with
+func hourlyEndDate(cal *WorkingCalendar, startDate time.Time, estimate time.Duration) time.Time {
+	endDate := startDate
+	remaining := estimate
+	for remaining > 0 {
+		var windowStart, windowEnd time.Time
+		for _, w := range cal.dayWindows(endDate) {
+			if end := clock(endDate, w.end); endDate.Before(end) {
+				windowStart, windowEnd = clock(endDate, w.start), end
+				if endDate.After(windowStart) {
+					windowStart = endDate
+				}
+				break
+			}
+		}
+		if windowEnd.IsZero() {
+			endDate = cal.nextDayStart(endDate)
+			continue
+		}
+		endDate = windowStart
+		step := time.Hour
+		if remaining < step {
+			step = remaining
+		}
+		if left := windowEnd.Sub(endDate); left < step {
+			step = left
+		}
+		remaining -= step
+		endDate = endDate.Add(step)
+	}
+	return endDate
+}
+
+func testCalendars(t *testing.T) map[string]*WorkingCalendar {
+	useCalendarData(
+		[]models.Holiday{
+			{HolidayName: "Whole day", HolidayDate: "2024-03-06"},
+			{HolidayName: "Afternoon", HolidayDate: "2024-03-13", StartTime: "14:30", EndTime: "18:00"},
+			{HolidayName: "Regional", HolidayDate: "2024-03-12", CalendarID: 1},
+		},
+		[]models.HolidayRule{{HolidayName: "Fixed", RuleType: RuleFixed, Month: 3, Day: 20}},
+		[]models.Leave{{Username: "tester", StartDate: "2024-03-14", EndDate: "2024-03-18"}},
+	)
+	calendars := make(map[string]*WorkingCalendar)
+	cal, err := ParseWorkingCalendar(DefaultWorkingCalendar)
+	assert.Nil(t, err)
+	calendars["default"] = cal
+
+	scheduled, err := cal.ForSchedule(models.UserSchedule{WorkingDays: "Monday,Tuesday,Wednesday,Thursday", HoursPerDay: 6, CalendarID: 1})
+	assert.Nil(t, err)
+	scheduled.username = "tester"
+	calendars["schedule"] = scheduled
+
+	night, err := ParseWorkingCalendar(models.WorkingCalendar{DayStart: "20:00", DayEnd: "23:59", Breaks: "21:15-21:40", WeekendDays: "Sunday"})
+	assert.Nil(t, err)
+	calendars["night"] = night
+	return calendars
+}
+
+func TestEndDateMatchesHourlyWalk(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.Nil(t, err)
+	for name, cal := range testCalendars(t) {
+		for _, loc := range []*time.Location{time.UTC, berlin} {
+			// every 25 minutes across a fortnight that includes the DST change
+			for start := time.Date(2024, 3, 22, 0, 0, 0, 0, loc); start.Before(time.Date(2024, 4, 5, 0, 0, 0, 0, loc)); start = start.Add(25 * time.Minute) {
+				for _, minutes := range []int{0, 1, 30, 60, 150, 180, 420, 480, 500, 540, 1020, 2400} {
+					estimate := time.Duration(minutes) * time.Minute
+					assert.Equal(t, hourlyEndDate(cal, start, estimate), cal.EndDate(start, estimate), "%s %s %dm", name, start, minutes)
+				}
+			}
+			for start := time.Date(2024, 3, 4, 8, 10, 0, 0, loc); start.Before(time.Date(2024, 3, 21, 0, 0, 0, 0, loc)); start = start.Add(50 * time.Minute) {
+				for _, minutes := range []int{45, 60, 300, 720, 1800, 1810} {
+					estimate := time.Duration(minutes) * time.Minute
+					assert.Equal(t, hourlyEndDate(cal, start, estimate), cal.EndDate(start, estimate), "%s %s %dm", name, start, minutes)
+				}
+			}
+		}
+	}
+}
+
+func TestEndDateLargeEstimate(t *testing.T) {
+	for name, cal := range testCalendars(t) {
+		start := time.Date(2024, 3, 4, 11, 30, 0, 0, time.UTC)
+		assert.Equal(t, hourlyEndDate(cal, start, 10000*time.Hour), cal.EndDate(start, 10000*time.Hour), name)
+	}
+
+	// the work done grows with the number of working days, not hours: at
+	// most one holiday lookup per 8 hour day of the default calendar
+	cal, _ := ParseWorkingCalendar(DefaultWorkingCalendar)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	allocs := testing.AllocsPerRun(10, func() { cal.EndDate(start, 10000*time.Hour) })
+	assert.LessOrEqual(t, allocs, float64(10000/8+10))
+}
+
+func BenchmarkEndDate10000Hours(b *testing.B) {
+	useCalendarData([]models.Holiday{{HolidayName: "Whole day", HolidayDate: "2024-03-06"}}, nil, nil)
+	cal, _ := ParseWorkingCalendar(DefaultWorkingCalendar)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cal.EndDate(start, 10000*time.Hour)
+	}
+}
+
+func BenchmarkHourlyEndDate10000Hours(b *testing.B) {
+	useCalendarData([]models.Holiday{{HolidayName: "Whole day", HolidayDate: "2024-03-06"}}, nil, nil)
+	cal, _ := ParseWorkingCalendar(DefaultWorkingCalendar)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hourlyEndDate(cal, start, 10000*time.Hour)
+	}
+}
+
+func TestStartDateIsLatestStart(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.Nil(t, err)
+	for name, cal := range testCalendars(t) {
+		for _, loc := range []*time.Location{time.UTC, berlin} {
+			for end := time.Date(2024, 3, 5, 0, 0, 0, 0, loc); end.Before(time.Date(2024, 4, 3, 0, 0, 0, 0, loc)); end = end.Add(35 * time.Minute) {
+				for _, minutes := range []int{20, 60, 240, 540, 555, 1560} {
+					estimate := time.Duration(minutes) * time.Minute
+					start := cal.StartDate(end, estimate)
+					finish := cal.EndDate(start, estimate)
+					assert.False(t, finish.After(end), "%s %s %dm finishes %s", name, end, minutes, finish)
+					// the windows consumed end exactly where the walk back began
+					assert.Equal(t, cal.workingTimeBetween(start, end), estimate, "%s %s %dm", name, end, minutes)
+					assert.True(t, cal.EndDate(start.Add(time.Minute), estimate).After(end), "%s %s %dm starts late enough", name, end, minutes)
+				}
+			}
+		}
+	}
+}
+
+func TestWorkingTimeBetween(t *testing.T) {
+	cal := testCalendars(t)["default"]
+	at := func(day, hour, minute int) time.Time { return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC) }
+	assert.Equal(t, 2*time.Hour, cal.workingTimeBetween(at(1, 17, 0), at(4, 10, 0)), "over the weekend")
+	assert.Equal(t, 90*time.Minute, cal.workingTimeBetween(at(4, 11, 30), at(4, 14, 0)), "over the lunch break")
+	assert.Equal(t, 8*time.Hour, cal.workingTimeBetween(at(5, 18, 0), at(7, 18, 0)), "over a holiday")
+	assert.Equal(t, 270*time.Minute, cal.workingTimeBetween(at(13, 9, 0), at(13, 18, 0)), "on a half-day holiday")
+	assert.Equal(t, -2*time.Hour, cal.workingTimeBetween(at(4, 10, 0), at(1, 17, 0)), "backwards")
+}
+
+func TestCalendarDayFlags(t *testing.T) {
+	calendars := testCalendars(t)
+	at := func(day int) time.Time { return time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC) }
+
+	cal := calendars["default"]
+	assert.True(t, cal.IsWeekend(at(9)))
+	assert.False(t, cal.IsWeekend(at(8)))
+	assert.Equal(t, []string{"Whole day"}, cal.Holidays(at(6)))
+	assert.Equal(t, []string{"Afternoon"}, cal.Holidays(at(13)), "part-day holidays are named too")
+	assert.Equal(t, []string{"Fixed"}, cal.Holidays(at(20)), "recurring holidays are expanded")
+	assert.Empty(t, cal.Holidays(at(12)), "regional holidays belong to another calendar")
+	assert.False(t, cal.OnLeave(at(14)), "the default calendar has no user")
+
+	scheduled := calendars["schedule"]
+	assert.True(t, scheduled.IsWeekend(at(8)), "Friday is outside the schedule")
+	assert.Equal(t, []string{"Regional"}, scheduled.Holidays(at(12)))
+	assert.True(t, scheduled.OnLeave(at(14)))
+	assert.False(t, scheduled.OnLeave(at(19)))
+}
+
+func TestForScheduleNeedsAWorkingDay(t *testing.T) {
+	cal, err := ParseWorkingCalendar(DefaultWorkingCalendar)
+	assert.Nil(t, err)
+	_, err = cal.ForSchedule(models.UserSchedule{WorkingDays: "Saturday"})
+	assert.Error(t, err, "a Saturday-only schedule on a Monday to Friday calendar")
+
+	scheduled, err := cal.ForSchedule(models.UserSchedule{WorkingDays: "Friday,Saturday"})
+	assert.Nil(t, err)
+	assert.True(t, scheduled.IsWeekend(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, scheduled.IsWeekend(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCalendarNeedsWorkingHours(t *testing.T) {
+	allBreak := DefaultWorkingCalendar
+	allBreak.Breaks = "09:00-12:00,12:00-18:00"
+	_, err := ParseWorkingCalendar(allBreak)
+	assert.Error(t, err, "breaks covering the whole day")
+
+	allBreak.Breaks = "09:00-18:00"
+	_, err = ParseWorkingCalendar(allBreak)
+	assert.Error(t, err, "a break covering the whole day")
+
+	cal, err := ParseWorkingCalendar(DefaultWorkingCalendar)
+	assert.Nil(t, err)
+	_, err = (&WorkingCalendar{weekend: cal.weekend}).ForSchedule(models.UserSchedule{HoursPerDay: 4})
+	assert.Error(t, err, "a calendar without working hours")
+	scheduled, err := cal.ForSchedule(models.UserSchedule{HoursPerDay: 4})
+	assert.Nil(t, err)
+	assert.Equal(t, []span{{9 * time.Hour, 12 * time.Hour}, {13 * time.Hour, 14 * time.Hour}}, scheduled.windows)
+}
@@ -0,0 +1,290 @@
+package taskAssignment
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// GanttBar is one assignment drawn on the chart
+type GanttBar struct {
+	AssignmentID uint   `json:"assignmentID"`
+	TaskID       uint   `json:"taskid"`
+	Title        string `json:"title"`
+	Hours        int    `json:"hours"`
+	Minutes      int    `json:"minutes"`
+	StartDate    string `json:"startDate"`
+	EndDate      string `json:"endDate"`
+	start, end   time.Time
+}
+
+// ShadedDay is a day without working time on the chart
+type ShadedDay struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+// GanttRow holds the bars and non-working days of one user
+type GanttRow struct {
+	Username string      `json:"username"`
+	Bars     []GanttBar  `json:"bars"`
+	Shaded   []ShadedDay `json:"shaded"`
+}
+
+// GanttChart is the data behind the Gantt endpoint
+type GanttChart struct {
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Shaded []ShadedDay `json:"shaded"`
+	Rows   []GanttRow  `json:"rows"`
+}
+
+// Gantt handles returning the assignments as Gantt chart data or SVG
+//
+//	@Summary		Gantt chart of task assignments
+//	@Description	Return assignment bars grouped by user, with weekends, holidays and leave shaded, as JSON or as a rendered SVG (format=svg). The range defaults to the span of all assignments, cut to a year.
+//	@Tags			Task Assignment
+//	@Produce		json
+//	@Produce		image/svg+xml
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string		true	"API Key"
+//
+//	@Param			from		query		string		false	"First day (YYYY-MM-DD)"
+//	@Param			to			query		string		false	"Last day (YYYY-MM-DD)"
+//	@Param			format		query		string		false	"Response format"	Enums(json, svg)
+//	@Param			dateFormat	query		string		false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	GanttChart	"Gantt chart data"
+//	@Failure		400			{object}	string		"invalid date format / range"
+//	@Router			/api/v2/taskAssignment/gantt [get]
+func Gantt() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var from, to time.Time
+		var err error
+		if c.Query("from") != "" {
+			if from, err = dates.ParseDay(c.Query("from")); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date format"})
+			}
+		}
+		if c.Query("to") != "" {
+			if to, err = dates.ParseDay(c.Query("to")); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date format"})
+			}
+		}
+		chart, err := buildGantt(from, to, dates.ResponseFormat(c))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if c.Query("format") == "svg" {
+			c.Set(fiber.HeaderContentType, "image/svg+xml")
+			return c.Status(fiber.StatusOK).SendString(renderGanttSVG(chart))
+		}
+		return c.Status(fiber.StatusOK).JSON(chart)
+	}
+}
+
+// localDay returns the calendar date of t as a UTC midnight, so bars in
+// different timezones line up on the same day columns
+func localDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func buildGantt(from, to time.Time, format string) (GanttChart, error) {
+	var assignments []models.TaskAssignment
+	database.DB.Find(&assignments)
+
+	rows := make(map[string]*GanttRow)
+	var first, last time.Time
+	for _, assignment := range assignments {
+		assignment = LocalAssignment(assignment)
+		start, end := assignment.Start_Date, assignment.End_Date
+		var task models.Task
+		database.DB.Where("id=?", assignment.TaskID).First(&task)
+		if rows[assignment.Username] == nil {
+			rows[assignment.Username] = &GanttRow{Username: assignment.Username, Bars: []GanttBar{}, Shaded: []ShadedDay{}}
+		}
+		row := rows[assignment.Username]
+		row.Bars = append(row.Bars, GanttBar{
+			AssignmentID: assignment.ID,
+			TaskID:       assignment.TaskID,
+			Title:        task.Title,
+			Hours:        WholeHours(ShareMinutes(assignment, EstimateMinutes(task))),
+			Minutes:      ShareMinutes(assignment, EstimateMinutes(task)),
+			StartDate:    dates.Format(start, format),
+			EndDate:      dates.Format(end, format),
+			start:        start,
+			end:          end,
+		})
+		if first.IsZero() || localDay(start).Before(first) {
+			first = localDay(start)
+		}
+		if localDay(end).After(last) {
+			last = localDay(end)
+		}
+	}
+	from, to, err := chartRange(from, to, first, last, localDay(time.Now()))
+	if err != nil {
+		return GanttChart{}, err
+	}
+
+	chart := GanttChart{
+		From:   from.Format("2006-01-02"),
+		To:     to.Format("2006-01-02"),
+		Shaded: shadedDays(ActiveWorkingCalendar(), from, to),
+		Rows:   []GanttRow{},
+	}
+	usernames := make([]string, 0, len(rows))
+	for username := range rows {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		row := rows[username]
+		sort.Slice(row.Bars, func(i, j int) bool { return row.Bars[i].start.Before(row.Bars[j].start) })
+		row.Shaded = shadedDays(UserWorkingCalendar(username), from, to)
+		chart.Rows = append(chart.Rows, *row)
+	}
+	return chart, nil
+}
+
+// chartRange fills the bounds not given with the first and last day of the
+// data, or today without data. A range given in full may span at most a
+// year; a bound taken from the data is moved to within a year of the other.
+func chartRange(from, to, first, last, today time.Time) (time.Time, time.Time, error) {
+	const maxRange = 366 * 24 * time.Hour
+	fromGiven, toGiven := !from.IsZero(), !to.IsZero()
+	if !fromGiven {
+		from = first
+	}
+	if !toGiven {
+		to = last
+	}
+	switch {
+	case from.IsZero() && to.IsZero():
+		from, to = today, today
+	case from.IsZero():
+		from = to
+	case to.IsZero():
+		to = from
+	}
+
+	switch {
+	case fromGiven && toGiven:
+		if to.Before(from) {
+			return from, to, fmt.Errorf("invalid range, to is before from")
+		}
+		if to.Sub(from) > maxRange {
+			return from, to, fmt.Errorf("invalid range, at most one year can be charted")
+		}
+	case toGiven:
+		if from.After(to) {
+			from = to
+		} else if to.Sub(from) > maxRange {
+			from = to.Add(-maxRange)
+		}
+	default:
+		if to.Before(from) {
+			to = from
+		} else if to.Sub(from) > maxRange {
+			to = from.Add(maxRange)
+		}
+	}
+	return from, to, nil
+}
+
+// shadedDays lists the days in [from, to] that have no working time
+func shadedDays(cal *WorkingCalendar, from, to time.Time) []ShadedDay {
+	shaded := []ShadedDay{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if cal.weekend[day.Weekday()] {
+			shaded = append(shaded, ShadedDay{Date: day.Format("2006-01-02"), Reason: "weekend"})
+			continue
+		}
+		if len(subtract(cal.windows, holidayClosures(cal.holidayCalendarID, day))) == 0 {
+			shaded = append(shaded, ShadedDay{Date: day.Format("2006-01-02"), Reason: "holiday"})
+			continue
+		}
+		if len(cal.dayWindows(day)) == 0 {
+			shaded = append(shaded, ShadedDay{Date: day.Format("2006-01-02"), Reason: "leave"})
+		}
+	}
+	return shaded
+}
+
+// renderGanttSVG draws one row per user with a column per day
+func renderGanttSVG(chart GanttChart) string {
+	const (
+		labelWidth = 160
+		dayWidth   = 28
+		rowHeight  = 30
+		header     = 40
+	)
+	from, _ := time.Parse("2006-01-02", chart.From)
+	to, _ := time.Parse("2006-01-02", chart.To)
+	days := int(to.Sub(from).Hours()/24) + 1
+	width := labelWidth + days*dayWidth
+	height := header + len(chart.Rows)*rowHeight
+	xOf := func(t time.Time) float64 {
+		day := localDay(t)
+		fraction := float64(t.Hour()*60+t.Minute()) / (24 * 60)
+		return labelWidth + (day.Sub(from).Hours()/24+fraction)*dayWidth
+	}
+	dayIndex := func(date string) int {
+		d, _ := time.Parse("2006-01-02", date)
+		return int(d.Sub(from).Hours() / 24)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="11">`+"\n", width, height)
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+
+	for i := 0; i < days; i++ {
+		day := from.AddDate(0, 0, i)
+		x := labelWidth + i*dayWidth
+		if i == 0 || day.Day() == 1 {
+			fmt.Fprintf(&b, `<text x="%d" y="14">%s</text>`+"\n", x+2, day.Format("Jan 2006"))
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="32" text-anchor="middle">%d</text>`+"\n", x+dayWidth/2, day.Day())
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#eeeeee"/>`+"\n", x, header, x, height)
+	}
+
+	for r, row := range chart.Rows {
+		y := header + r*rowHeight
+		for _, shaded := range row.Shaded {
+			fill := "#e0e0e0"
+			if shaded.Reason != "weekend" {
+				fill = "#f4cccc"
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s %s</title></rect>`+"\n",
+				labelWidth+dayIndex(shaded.Date)*dayWidth, y, dayWidth, rowHeight, fill, shaded.Date, shaded.Reason)
+		}
+		fmt.Fprintf(&b, `<text x="4" y="%d">%s</text>`+"\n", y+rowHeight/2+4, html.EscapeString(row.Username))
+		for _, bar := range row.Bars {
+			x1, x2 := xOf(bar.start), xOf(bar.end)
+			if x2 < labelWidth || x1 > float64(width) {
+				continue
+			}
+			if x1 < labelWidth {
+				x1 = labelWidth
+			}
+			if x2 > float64(width) {
+				x2 = float64(width)
+			}
+			if x2-x1 < 2 {
+				x2 = x1 + 2
+			}
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%d" width="%.1f" height="%d" rx="3" fill="#4a86e8"><title>%s (%s - %s)</title></rect>`+"\n",
+				x1, y+6, x2-x1, rowHeight-12, html.EscapeString(bar.Title), html.EscapeString(bar.StartDate), html.EscapeString(bar.EndDate))
+		}
+		fmt.Fprintf(&b, `<line x1="0" y1="%d" x2="%d" y2="%d" stroke="#cccccc"/>`+"\n", y+rowHeight, width, y+rowHeight)
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
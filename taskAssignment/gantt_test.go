@@ -0,0 +1,48 @@
+package taskAssignment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChartRange(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	var none time.Time
+	today := day("2026-10-17")
+	first, last := day("2024-01-01"), day("2026-06-30")
+
+	from, to, err := chartRange(none, none, first, last, today)
+	assert.NoError(t, err)
+	assert.Equal(t, first, from)
+	assert.Equal(t, day("2025-01-01"), to)
+
+	from, to, err = chartRange(none, day("2026-03-01"), first, last, today)
+	assert.NoError(t, err)
+	assert.Equal(t, day("2025-02-28"), from)
+	assert.Equal(t, day("2026-03-01"), to)
+
+	from, to, err = chartRange(day("2026-09-01"), none, first, last, today)
+	assert.NoError(t, err)
+	assert.Equal(t, day("2026-09-01"), from)
+	assert.Equal(t, day("2026-09-01"), to)
+
+	from, to, err = chartRange(day("2026-09-01"), none, none, none, today)
+	assert.NoError(t, err)
+	assert.Equal(t, day("2026-09-01"), from)
+	assert.Equal(t, day("2026-09-01"), to)
+
+	from, to, err = chartRange(none, none, none, none, today)
+	assert.NoError(t, err)
+	assert.Equal(t, today, from)
+	assert.Equal(t, today, to)
+
+	_, _, err = chartRange(first, last, none, none, today)
+	assert.Error(t, err)
+	_, _, err = chartRange(last, first, none, none, today)
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package taskAssignment
+
+import (
+	"sync"
+	"time"
+
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// holidayKey identifies one day of one holiday calendar
+type holidayKey struct {
+	calendarID uint
+	date       string
+}
+
+// holidayYear identifies the recurring holidays of one calendar in one year
+type holidayYear struct {
+	calendarID uint
+	year       int
+}
+
+// holidayIndex is the in-memory copy of the holidays, loaded once and
+// indexed by calendar and date; recurring rules are expanded per year on
+// first use
+type holidayIndex struct {
+	closed   map[holidayKey][]span
+	names    map[holidayKey][]string
+	rules    map[uint][]models.HolidayRule
+	expanded map[holidayYear]bool
+}
+
+// leaveIndex is the in-memory copy of the leaves, indexed by user
+type leaveIndex map[string][]models.Leave
+
+var (
+	holidayMu    sync.Mutex
+	holidayCache *holidayIndex
+	leaveMu      sync.Mutex
+	leaveCache   leaveIndex
+)
+
+// InvalidateHolidayCache drops the in-memory holiday calendar; it must be
+// called after holidays, holiday rules or holiday calendars are written
+func InvalidateHolidayCache() {
+	holidayMu.Lock()
+	holidayCache = nil
+	holidayMu.Unlock()
+}
+
+// InvalidateLeaveCache drops the in-memory leaves; it must be called after
+// leaves are written
+func InvalidateLeaveCache() {
+	leaveMu.Lock()
+	leaveCache = nil
+	leaveMu.Unlock()
+}
+
+// loadHolidayIndex reads every stored holiday and rule in two queries
+func loadHolidayIndex(db *gorm.DB) *holidayIndex {
+	var holidays []models.Holiday
+	db.Find(&holidays)
+	var rules []models.HolidayRule
+	db.Find(&rules)
+	return newHolidayIndex(holidays, rules)
+}
+
+// newHolidayIndex indexes holidays and recurring rules
+func newHolidayIndex(holidays []models.Holiday, rules []models.HolidayRule) *holidayIndex {
+	index := &holidayIndex{
+		closed:   make(map[holidayKey][]span),
+		names:    make(map[holidayKey][]string),
+		rules:    make(map[uint][]models.HolidayRule),
+		expanded: make(map[holidayYear]bool),
+	}
+	index.add(holidays)
+	for _, rule := range rules {
+		index.rules[rule.CalendarID] = append(index.rules[rule.CalendarID], rule)
+	}
+	return index
+}
+
+// add indexes the closed hours of holidays
+func (index *holidayIndex) add(holidays []models.Holiday) {
+	for _, holiday := range holidays {
+		start, end, err := ParseHolidayHours(holiday)
+		if err != nil {
+			continue
+		}
+		key := holidayKey{holiday.CalendarID, string(holiday.HolidayDate)}
+		index.closed[key] = append(index.closed[key], span{start, end})
+		index.names[key] = append(index.names[key], holiday.HolidayName)
+	}
+}
+
+// expand adds the recurring holidays of the year of date in a holiday
+// calendar, once
+func (index *holidayIndex) expand(calendarID uint, date time.Time) {
+	year := holidayYear{calendarID, date.Year()}
+	if !index.expanded[year] {
+		index.expanded[year] = true
+		index.add(ExpandHolidayRules(index.rules[calendarID], date.Year()))
+	}
+}
+
+// closures returns the closed parts of date in a holiday calendar
+func (index *holidayIndex) closures(calendarID uint, date time.Time) []span {
+	index.expand(calendarID, date)
+	return index.closed[holidayKey{calendarID, date.Format("2006-01-02")}]
+}
+
+// holidayNames returns the names of the whole and part-day holidays on date
+// in a holiday calendar
+func (index *holidayIndex) holidayNames(calendarID uint, date time.Time) []string {
+	index.expand(calendarID, date)
+	return index.names[holidayKey{calendarID, date.Format("2006-01-02")}]
+}
+
+// holidayClosures returns the closed parts of the day containing date in the
+// given holiday calendar; calendar 0 is the company-wide holiday list
+func holidayClosures(calendarID uint, date time.Time) []span {
+	holidayMu.Lock()
+	defer holidayMu.Unlock()
+	if holidayCache == nil {
+		holidayCache = loadHolidayIndex(database.DB)
+	}
+	return holidayCache.closures(calendarID, date)
+}
+
+// leaveClosures returns the parts of the day containing date the user is on leave
+func leaveClosures(username string, date time.Time) []span {
+	leaveMu.Lock()
+	if leaveCache == nil {
+		var leaves []models.Leave
+		database.DB.Find(&leaves)
+		leaveCache = make(leaveIndex)
+		for _, leave := range leaves {
+			leaveCache[leave.Username] = append(leaveCache[leave.Username], leave)
+		}
+	}
+	leaves := leaveCache[username]
+	leaveMu.Unlock()
+
+	day := date.Format("2006-01-02")
+	for _, leave := range leaves {
+		if leave.StartDate <= day && leave.EndDate >= day {
+			return []span{{0, 24 * time.Hour}}
+		}
+	}
+	return nil
+}
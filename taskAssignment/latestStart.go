@@ -0,0 +1,104 @@
+package taskAssignment
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// LatestStartRequest asks for the latest start that meets a deadline; the
+// estimate is given in minutes or whole hours, or taken from the task when
+// taskid is given
+type LatestStartRequest struct {
+	Username         string `json:"username"`
+	TaskID           uint   `json:"taskid"`
+	EstimatedHours   int    `json:"estimatedHours"`
+	EstimatedMinutes int    `json:"estimatedMinutes"`
+	EndDate          string `json:"endDate"`
+	Timezone         string `json:"timezone"`
+}
+
+// LatestStartResult is the latest start for a deadline and estimate
+type LatestStartResult struct {
+	Username         string `json:"username"`
+	EstimatedHours   int    `json:"estimatedHours"`
+	EstimatedMinutes int    `json:"estimatedMinutes"`
+	StartDate        string `json:"startDate"`
+	EndDate          string `json:"endDate"`
+}
+
+// LatestStart handles computing the latest start for a deadline
+//
+//	@Summary		Latest start for a deadline
+//	@Description	Work back from endDate through working hours, breaks, weekends, holidays and leave to the latest start that still finishes estimatedMinutes or estimatedHours (or the task's estimate) by the deadline. Uses the user's working schedule when username is given, otherwise the active working calendar. endDate in the response is when the work actually finishes.
+//	@Tags			Task Assignment
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			request		body		LatestStartRequest	true	"Deadline and estimate"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	LatestStartResult	"Latest start computed successfully"
+//	@Failure		400			{object}	string				"Invalid request payload / invalid date time format"
+//	@Failure		404			{object}	string				"Username doesn't exist / Task not found"
+//	@Router			/api/v2/taskAssignment/latestStart [post]
+func LatestStart() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := new(LatestStartRequest)
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if request.Username != "" {
+			var existingUser models.User
+			database.DB.Where("username=?", request.Username).First(&existingUser)
+			if len(existingUser.Username) == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Username doesn't exists"})
+			}
+		}
+		minutes := RequestedMinutes(request.EstimatedHours, request.EstimatedMinutes)
+		if request.TaskID != 0 {
+			var existingTask models.Task
+			database.DB.Where("id=?", request.TaskID).First(&existingTask)
+			if existingTask.ID == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Task not found"})
+			}
+			minutes = EstimateMinutes(existingTask)
+		}
+		if minutes < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "estimate must not be negative"})
+		}
+
+		loc := UserLocation(request.Username)
+		if request.Timezone != "" {
+			var err error
+			if loc, err = LoadLocation(request.Timezone); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		deadline, err := dates.Parse(request.EndDate, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+		}
+
+		cal := ActiveWorkingCalendar()
+		if request.Username != "" {
+			cal = UserWorkingCalendar(request.Username)
+		}
+		estimate := time.Duration(minutes) * time.Minute
+		start := cal.StartDate(deadline, estimate)
+		format := dates.ResponseFormat(c)
+		return c.Status(fiber.StatusOK).JSON(LatestStartResult{
+			Username:         request.Username,
+			EstimatedHours:   WholeHours(minutes),
+			EstimatedMinutes: minutes,
+			StartDate:        dates.Format(start, format),
+			EndDate:          dates.Format(cal.EndDate(start, estimate), format),
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package taskAssignment
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/saran-crayonte/task/models"
+)
+
+// Holiday rule types
+const (
+	RuleFixed      = "fixed"
+	RuleNthWeekday = "nthWeekday"
+	RuleEaster     = "easter"
+)
+
+// ValidateHolidayRule checks that a rule has the fields its type needs
+func ValidateHolidayRule(rule models.HolidayRule) error {
+	switch rule.RuleType {
+	case RuleFixed:
+		// a leap year has every day a month can have, 29 February included
+		if rule.Month < 1 || rule.Month > 12 || rule.Day < 1 || rule.Day > daysIn(time.Month(rule.Month), 2024) {
+			return errors.New("fixed rules need a valid month and day")
+		}
+	case RuleNthWeekday:
+		if rule.Month < 1 || rule.Month > 12 {
+			return errors.New("nthWeekday rules need a valid month")
+		}
+		if _, ok := weekdays[strings.ToLower(strings.TrimSpace(rule.Weekday))]; !ok {
+			return errors.New("nthWeekday rules need a valid weekday")
+		}
+		if rule.Nth == 0 || rule.Nth < -1 || rule.Nth > 5 {
+			return errors.New("nth must be 1 to 5, or -1 for the last weekday of the month")
+		}
+	case RuleEaster:
+		if rule.Offset < -366 || rule.Offset > 366 {
+			return errors.New("easter offset must be within a year")
+		}
+	default:
+		return errors.New("rule type must be fixed, nthWeekday or easter")
+	}
+	_, _, err := ParseHolidayHours(models.Holiday{StartTime: rule.StartTime, EndTime: rule.EndTime})
+	return err
+}
+
+// daysIn returns the number of days of month in year
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// HolidayOccurrence returns the date a rule falls on in the given year; ok is
+// false when the rule has no occurrence that year (e.g. 29 February)
+func HolidayOccurrence(rule models.HolidayRule, year int) (date time.Time, ok bool) {
+	switch rule.RuleType {
+	case RuleFixed:
+		date = time.Date(year, time.Month(rule.Month), rule.Day, 0, 0, 0, 0, time.UTC)
+		return date, date.Month() == time.Month(rule.Month)
+	case RuleNthWeekday:
+		weekday, found := weekdays[strings.ToLower(strings.TrimSpace(rule.Weekday))]
+		if !found {
+			return time.Time{}, false
+		}
+		if rule.Nth == -1 {
+			date = time.Date(year, time.Month(rule.Month)+1, 0, 0, 0, 0, 0, time.UTC)
+			for date.Weekday() != weekday {
+				date = date.AddDate(0, 0, -1)
+			}
+			return date, true
+		}
+		date = time.Date(year, time.Month(rule.Month), 1, 0, 0, 0, 0, time.UTC)
+		for date.Weekday() != weekday {
+			date = date.AddDate(0, 0, 1)
+		}
+		date = date.AddDate(0, 0, 7*(rule.Nth-1))
+		return date, date.Month() == time.Month(rule.Month)
+	case RuleEaster:
+		return easterSunday(year).AddDate(0, 0, rule.Offset), true
+	}
+	return time.Time{}, false
+}
+
+// easterSunday computes Western Easter with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// ExpandHolidayRules generates the concrete holidays of the rules for a year
+func ExpandHolidayRules(rules []models.HolidayRule, year int) []models.Holiday {
+	var holidays []models.Holiday
+	for _, rule := range rules {
+		// Easter offsets can move an occurrence into a neighbouring year
+		for _, y := range []int{year - 1, year, year + 1} {
+			date, ok := HolidayOccurrence(rule, y)
+			if !ok || date.Year() != year {
+				continue
+			}
+			holidays = append(holidays, models.Holiday{
+				HolidayName: rule.HolidayName,
+				HolidayDate: models.Date(date.Format("2006-01-02")),
+				StartTime:   rule.StartTime,
+				EndTime:     rule.EndTime,
+				CalendarID:  rule.CalendarID,
+				RuleID:      rule.ID,
+			})
+		}
+	}
+	return holidays
+}
@@ -0,0 +1,74 @@
+package taskAssignment
+
+import (
+	"testing"
+
+	"github.com/saran-crayonte/task/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolidayOccurrence(t *testing.T) {
+	tests := []struct {
+		name string
+		rule models.HolidayRule
+		year int
+		want string
+		ok   bool
+	}{
+		{"easter 2024", models.HolidayRule{RuleType: RuleEaster}, 2024, "2024-03-31", true},
+		{"easter 2025", models.HolidayRule{RuleType: RuleEaster}, 2025, "2025-04-20", true},
+		{"good friday", models.HolidayRule{RuleType: RuleEaster, Offset: -2}, 2025, "2025-04-18", true},
+		{"easter offset into next year", models.HolidayRule{RuleType: RuleEaster, Offset: 300}, 2024, "2025-01-25", true},
+		{"easter offset into previous year", models.HolidayRule{RuleType: RuleEaster, Offset: -100}, 2026, "2025-12-26", true},
+		{"fourth thursday", models.HolidayRule{RuleType: RuleNthWeekday, Month: 11, Weekday: "Thursday", Nth: 4}, 2024, "2024-11-28", true},
+		{"first monday", models.HolidayRule{RuleType: RuleNthWeekday, Month: 9, Weekday: "monday", Nth: 1}, 2025, "2025-09-01", true},
+		{"no fifth monday", models.HolidayRule{RuleType: RuleNthWeekday, Month: 2, Weekday: "Monday", Nth: 5}, 2025, "", false},
+		{"last monday", models.HolidayRule{RuleType: RuleNthWeekday, Month: 5, Weekday: "Monday", Nth: -1}, 2025, "2025-05-26", true},
+		{"last weekday on the last day", models.HolidayRule{RuleType: RuleNthWeekday, Month: 1, Weekday: "Friday", Nth: -1}, 2025, "2025-01-31", true},
+		{"last weekday in december", models.HolidayRule{RuleType: RuleNthWeekday, Month: 12, Weekday: "Wednesday", Nth: -1}, 2025, "2025-12-31", true},
+		{"29 february leap year", models.HolidayRule{RuleType: RuleFixed, Month: 2, Day: 29}, 2024, "2024-02-29", true},
+		{"29 february common year", models.HolidayRule{RuleType: RuleFixed, Month: 2, Day: 29}, 2025, "", false},
+		{"fixed", models.HolidayRule{RuleType: RuleFixed, Month: 12, Day: 25}, 2025, "2025-12-25", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, ok := HolidayOccurrence(tt.rule, tt.year)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, date.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestExpandHolidayRulesAcrossYears(t *testing.T) {
+	dates := func(holidays []models.Holiday) []models.Date {
+		var result []models.Date
+		for _, holiday := range holidays {
+			result = append(result, holiday.HolidayDate)
+		}
+		return result
+	}
+	before := models.HolidayRule{RuleType: RuleEaster, Offset: -100}
+	after := models.HolidayRule{RuleType: RuleEaster, Offset: 300}
+	leap := models.HolidayRule{RuleType: RuleFixed, Month: 2, Day: 29}
+
+	assert.Equal(t, []models.Date{"2025-01-10", "2025-12-26"}, dates(ExpandHolidayRules([]models.HolidayRule{before}, 2025)))
+	assert.Equal(t, []models.Date{"2025-01-25"}, dates(ExpandHolidayRules([]models.HolidayRule{after}, 2025)))
+	assert.Empty(t, ExpandHolidayRules([]models.HolidayRule{leap}, 2025))
+	assert.Equal(t, []models.Date{"2028-02-29"}, dates(ExpandHolidayRules([]models.HolidayRule{leap}, 2028)))
+}
+
+func TestValidateFixedHolidayRule(t *testing.T) {
+	fixed := func(month, day int) models.HolidayRule {
+		return models.HolidayRule{RuleType: RuleFixed, Month: month, Day: day}
+	}
+	assert.NoError(t, ValidateHolidayRule(fixed(2, 29)))
+	assert.NoError(t, ValidateHolidayRule(fixed(12, 31)))
+	assert.NoError(t, ValidateHolidayRule(fixed(4, 30)))
+	assert.Error(t, ValidateHolidayRule(fixed(2, 30)))
+	assert.Error(t, ValidateHolidayRule(fixed(4, 31)))
+	assert.Error(t, ValidateHolidayRule(fixed(11, 31)))
+	assert.Error(t, ValidateHolidayRule(fixed(1, 0)))
+	assert.Error(t, ValidateHolidayRule(fixed(13, 1)))
+}
@@ -0,0 +1,114 @@
+package taskAssignment
+
+import (
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// HolidayChange is a day of a holiday calendar whose closed hours changed;
+// an empty Date stands for every day, as when a recurring rule changes
+type HolidayChange struct {
+	CalendarID uint
+	Date       string
+}
+
+// RescheduleForHolidays recomputes, through tx, only the assignments whose
+// assignee uses a changed holiday calendar and whose dates span a changed
+// day, then moves their dependents; it returns the number of assignments
+// whose dates changed. The holidays are read through tx into a private
+// index, so the uncommitted change is seen without reaching other requests;
+// callers must InvalidateHolidayCache once the transaction commits.
+func RescheduleForHolidays(tx *gorm.DB, changes ...HolidayChange) (int, error) {
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	var schedules []models.UserSchedule
+	tx.Find(&schedules)
+	calendarOf := make(map[string]uint)
+	for _, schedule := range schedules {
+		calendarOf[schedule.Username] = schedule.CalendarID
+	}
+
+	var assignments []models.TaskAssignment
+	tx.Find(&assignments)
+	var affected []models.TaskAssignment
+	for _, assignment := range assignments {
+		local := LocalAssignment(assignment)
+		from, to := local.Start_Date.Format("2006-01-02"), local.End_Date.Format("2006-01-02")
+		for _, change := range changes {
+			if change.CalendarID != calendarOf[assignment.Username] {
+				continue
+			}
+			// a day before the start cannot move the assignment, and a day
+			// after the end only matters once the end date reaches it
+			if change.Date == "" || (from <= change.Date && change.Date <= to) {
+				affected = append(affected, assignment)
+				break
+			}
+		}
+	}
+	if len(affected) == 0 {
+		return 0, nil
+	}
+	return recomputeAssignments(tx, loadHolidayIndex(tx), affected)
+}
+
+// RecomputeAssignments recomputes the end dates of the given assignments in
+// one transaction, as after a change to their assignee's leave or schedule;
+// other shares of the same tasks keep their dates
+func RecomputeAssignments(assignments []models.TaskAssignment) (int, error) {
+	changed := 0
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		changed, err = recomputeAssignments(tx, nil, assignments)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+// recomputeAssignments recomputes the end dates of assignments from their
+// start dates and moves the dependents of their tasks, returning the number
+// of assignments whose dates changed; a nil holidays uses the shared holiday
+// calendar
+func recomputeAssignments(db *gorm.DB, holidays *holidayIndex, assignments []models.TaskAssignment) (int, error) {
+	changed := 0
+	tasks := make(map[uint]models.Task)
+	var taskIDs []uint
+	for _, assignment := range assignments {
+		task, ok := tasks[assignment.TaskID]
+		if !ok {
+			db.Where("id=?", assignment.TaskID).First(&task)
+			tasks[assignment.TaskID] = task
+			taskIDs = append(taskIDs, assignment.TaskID)
+		}
+		if task.ID == 0 {
+			continue
+		}
+		start := LocalAssignment(assignment).Start_Date
+		end := userWorkingCalendar(db, holidays, assignment.Username).EndDate(start, shareDuration(assignment, task))
+		if end.Equal(assignment.End_Date) {
+			continue
+		}
+		err := db.Model(&assignment).Updates(models.TaskAssignment{
+			Start_Date: start,
+			End_Date:   end,
+		}).Error
+		if err != nil {
+			return changed, err
+		}
+		changed++
+	}
+	for _, taskID := range taskIDs {
+		n, err := propagate(db, holidays, taskID, make(map[uint]bool))
+		changed += n
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,126 @@
+package taskAssignment
+
+import (
+	"time"
+
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"gorm.io/gorm"
+)
+
+// EstimateMinutes returns a task's estimate in minutes; tasks written before
+// minute estimates only carry whole hours
+func EstimateMinutes(task models.Task) int {
+	if task.EstimatedMinutes > 0 {
+		return task.EstimatedMinutes
+	}
+	return task.EstimatedHours * 60
+}
+
+// RequestedMinutes returns an estimate given as minutes, or as whole hours by
+// clients that only know hours; minutes win when both are given
+func RequestedMinutes(hours, minutes int) int {
+	if minutes != 0 {
+		return minutes
+	}
+	return hours * 60
+}
+
+// WholeHours rounds minutes up to the whole hours reported to clients that
+// only know hours
+func WholeHours(minutes int) int {
+	if minutes <= 0 {
+		return 0
+	}
+	return (minutes + 59) / 60
+}
+
+// ShareMinutes returns the part of the task's estimate, in minutes, an
+// assignment covers; assignments stored without a share carry the whole
+// estimate
+func ShareMinutes(assignment models.TaskAssignment, estimateMinutes int) int {
+	if assignment.Minutes > 0 {
+		return assignment.Minutes
+	}
+	if assignment.Hours > 0 {
+		return assignment.Hours * 60
+	}
+	return estimateMinutes
+}
+
+// shareDuration is the work an assignment covers of its task
+func shareDuration(assignment models.TaskAssignment, task models.Task) time.Duration {
+	return time.Duration(ShareMinutes(assignment, EstimateMinutes(task))) * time.Minute
+}
+
+// UnassignedMinutes returns the minutes of task's estimate not yet shared out
+// to an assignee, leaving out the assignment excludeID
+func UnassignedMinutes(task models.Task, excludeID uint) int {
+	var assignments []models.TaskAssignment
+	database.DB.Where("task_id=? AND id<>?", task.ID, excludeID).Find(&assignments)
+	estimate := EstimateMinutes(task)
+	remaining := estimate
+	for _, assignment := range assignments {
+		remaining -= ShareMinutes(assignment, estimate)
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// TaskSpan returns when the first share of a task starts and when the last
+// share ends, which is when the task as a whole is finished
+func TaskSpan(taskID uint) (start, end time.Time, ok bool) {
+	return taskSpan(database.DB, taskID)
+}
+
+func taskSpan(db *gorm.DB, taskID uint) (start, end time.Time, ok bool) {
+	var span struct {
+		FirstStart *time.Time
+		LastEnd    *time.Time
+	}
+	db.Model(&models.TaskAssignment{}).
+		Select("min(start_date) AS first_start, max(end_date) AS last_end").
+		Where("task_id=?", taskID).
+		Scan(&span)
+	if span.FirstStart == nil || span.LastEnd == nil {
+		return start, end, false
+	}
+	return *span.FirstStart, *span.LastEnd, true
+}
+
+// RescaleShares scales the explicit shares of a task when its estimate
+// changes from one total to another, in minutes, rounding so the shares keep
+// their sum
+func RescaleShares(taskID uint, from, to int) {
+	if from <= 0 || to <= 0 || from == to {
+		return
+	}
+	var assignments []models.TaskAssignment
+	database.DB.Where("task_id=? AND (minutes>0 OR hours>0)", taskID).Order("id").Find(&assignments)
+	shares := make([]int, len(assignments))
+	for i, assignment := range assignments {
+		shares[i] = ShareMinutes(assignment, 0)
+	}
+	for i, minutes := range rescaledShares(shares, from, to) {
+		database.DB.Model(&assignments[i]).Updates(map[string]interface{}{"minutes": minutes, "hours": WholeHours(minutes)})
+	}
+}
+
+// rescaledShares scales shares by to/from, rounding the running total so the
+// scaled shares add up to the scaled sum; every share keeps at least a minute
+func rescaledShares(shares []int, from, to int) []int {
+	scaledShares := make([]int, len(shares))
+	done, scaled := 0, 0
+	for i, share := range shares {
+		done += share
+		minutes := (done*to+from/2)/from - scaled
+		if minutes < 1 {
+			minutes = 1
+		}
+		scaled += minutes
+		scaledShares[i] = minutes
+	}
+	return scaledShares
+}
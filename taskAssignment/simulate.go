@@ -0,0 +1,264 @@
+package taskAssignment
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// SimulationRequest lists proposed changes that are applied in memory only.
+// A holiday with the id of a stored holiday replaces it, one without an id
+// is added; tasks carry the proposed estimatedMinutes or estimatedHours of
+// existing tasks.
+type SimulationRequest struct {
+	Holidays         []models.Holiday `json:"holidays"`
+	RemoveHolidayIDs []uint           `json:"removeHolidayIDs"`
+	Tasks            []models.Task    `json:"tasks"`
+}
+
+// SimulatedAssignment is an assignment's end date before and after the
+// proposed changes
+type SimulatedAssignment struct {
+	ID           uint   `json:"id"`
+	TaskID       uint   `json:"taskid"`
+	Username     string `json:"username"`
+	OldStartDate string `json:"oldStartDate"`
+	NewStartDate string `json:"newStartDate"`
+	OldEndDate   string `json:"oldEndDate"`
+	NewEndDate   string `json:"newEndDate"`
+	Changed      bool   `json:"changed"`
+}
+
+// SimulationResult is the impact of the proposed changes on every assignment
+type SimulationResult struct {
+	Changed     int                   `json:"changed"`
+	Assignments []SimulatedAssignment `json:"assignments"`
+}
+
+// simulatedShare is an assignment being rescheduled in memory
+type simulatedShare struct {
+	assignment models.TaskAssignment
+	minutes    int
+	loc        *time.Location
+	calendar   *WorkingCalendar
+	oldStart   time.Time
+	start, end time.Time
+}
+
+// work is the share's part of its task's estimate
+func (share *simulatedShare) work() time.Duration {
+	return time.Duration(share.minutes) * time.Minute
+}
+
+// Simulate handles previewing the impact of holiday and estimate changes
+//
+//	@Summary		Simulate holiday and estimate changes
+//	@Description	Apply proposed holidays (added, replaced by id or removed) and task estimate changes in memory, reschedule every assignment as the live update would, including moving dependent tasks, and return each assignment's old and new dates. Nothing is written.
+//	@Tags			Task Assignment
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			request		body		SimulationRequest	true	"Proposed changes"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	SimulationResult	"Old and new dates of every assignment"
+//	@Failure		400			{object}	string				"Invalid request payload / invalid holiday"
+//	@Failure		404			{object}	string				"Task not found"
+//	@Router			/api/v2/taskAssignment/simulate [post]
+func Simulate() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := new(SimulationRequest)
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		for i, holiday := range request.Holidays {
+			day, err := dates.ParseDay(string(holiday.HolidayDate))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+			}
+			request.Holidays[i].HolidayDate = models.Date(day.Format(dates.DayLayout))
+			if _, _, err := ParseHolidayHours(holiday); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		estimates := make(map[uint]int)
+		for _, task := range request.Tasks {
+			var existingTask models.Task
+			database.DB.Where("id=?", task.ID).First(&existingTask)
+			if existingTask.ID == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Task not found", "taskid": task.ID})
+			}
+			// as in UpdateTasks, rounded up hours sent back unchanged and a
+			// missing estimate keep the current estimate
+			if task.EstimatedMinutes == 0 && task.EstimatedHours == WholeHours(EstimateMinutes(existingTask)) {
+				continue
+			}
+			minutes := RequestedMinutes(task.EstimatedHours, task.EstimatedMinutes)
+			if minutes < 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "estimate must not be negative"})
+			}
+			if minutes == 0 {
+				continue
+			}
+			estimates[task.ID] = minutes
+		}
+		result, err := simulate(*request, estimates, dates.ResponseFormat(c))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusOK).JSON(result)
+	}
+}
+
+// simulatedHolidays returns the stored holidays with the proposed ones
+// added, replaced or removed
+func simulatedHolidays(request SimulationRequest) ([]models.Holiday, error) {
+	var stored []models.Holiday
+	database.DB.Find(&stored)
+	removed := make(map[uint]bool)
+	for _, id := range request.RemoveHolidayIDs {
+		removed[id] = true
+	}
+	replaced := make(map[uint]models.Holiday)
+	var added []models.Holiday
+	for _, holiday := range request.Holidays {
+		if holiday.ID == 0 {
+			added = append(added, holiday)
+		} else {
+			replaced[holiday.ID] = holiday
+		}
+	}
+	var holidays []models.Holiday
+	for _, holiday := range stored {
+		if removed[holiday.ID] {
+			continue
+		}
+		if replacement, ok := replaced[holiday.ID]; ok {
+			holiday = replacement
+			delete(replaced, holiday.ID)
+		}
+		holidays = append(holidays, holiday)
+	}
+	if len(replaced) > 0 {
+		return nil, errors.New("holiday to replace not found")
+	}
+	return append(holidays, added...), nil
+}
+
+// simulate reschedules every assignment in memory the way a live holiday or
+// estimate update followed by dependency propagation would
+func simulate(request SimulationRequest, estimates map[uint]int, format string) (SimulationResult, error) {
+	result := SimulationResult{Assignments: []SimulatedAssignment{}}
+	holidays, err := simulatedHolidays(request)
+	if err != nil {
+		return result, err
+	}
+	var rules []models.HolidayRule
+	database.DB.Find(&rules)
+	index := newHolidayIndex(holidays, rules)
+
+	var assignments []models.TaskAssignment
+	database.DB.Order("id").Find(&assignments)
+	calendars := make(map[string]*WorkingCalendar)
+	tasks := make(map[uint]models.Task)
+	shares := make(map[uint][]*simulatedShare)
+	var ordered []*simulatedShare
+	for _, assignment := range assignments {
+		task, ok := tasks[assignment.TaskID]
+		if !ok {
+			database.DB.Where("id=?", assignment.TaskID).First(&task)
+			tasks[assignment.TaskID] = task
+		}
+		loc := AssignmentLocation(assignment)
+		start := assignment.Start_Date.In(loc)
+		if calendars[assignment.Username] == nil {
+			calendars[assignment.Username] = UserWorkingCalendar(assignment.Username)
+			calendars[assignment.Username].holidays = index
+		}
+		share := &simulatedShare{
+			assignment: assignment,
+			minutes:    ShareMinutes(assignment, EstimateMinutes(task)),
+			loc:        loc,
+			calendar:   calendars[assignment.Username],
+			oldStart:   start,
+			start:      start,
+		}
+		shares[assignment.TaskID] = append(shares[assignment.TaskID], share)
+		ordered = append(ordered, share)
+	}
+
+	// a changed estimate scales the explicit shares, as RescaleShares does
+	for taskID, estimate := range estimates {
+		from := EstimateMinutes(tasks[taskID])
+		var explicit []*simulatedShare
+		var minutes []int
+		for _, share := range shares[taskID] {
+			if share.assignment.Minutes > 0 || share.assignment.Hours > 0 {
+				explicit = append(explicit, share)
+				minutes = append(minutes, share.minutes)
+			} else {
+				share.minutes = estimate
+			}
+		}
+		if from > 0 && estimate > 0 && from != estimate {
+			for i, scaled := range rescaledShares(minutes, from, estimate) {
+				explicit[i].minutes = scaled
+			}
+		}
+	}
+	for _, share := range ordered {
+		share.end = share.calendar.EndDate(share.start, share.work())
+	}
+
+	// finish-to-start: push shares after the last share of their
+	// predecessors, repeating until nothing moves; the pass limit stops a
+	// dependency cycle in stored data
+	var dependencies []models.TaskDependency
+	database.DB.Find(&dependencies)
+	for pass := 0; pass <= len(shares); pass++ {
+		moved := false
+		for _, dependency := range dependencies {
+			var predecessorEnd time.Time
+			for _, share := range shares[dependency.DependsOnID] {
+				if share.end.After(predecessorEnd) {
+					predecessorEnd = share.end
+				}
+			}
+			for _, share := range shares[dependency.TaskID] {
+				if predecessorEnd.After(share.start) {
+					share.start = predecessorEnd.In(share.loc)
+					share.end = share.calendar.EndDate(share.start, share.work())
+					moved = true
+				}
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	for _, share := range ordered {
+		simulated := SimulatedAssignment{
+			ID:           share.assignment.ID,
+			TaskID:       share.assignment.TaskID,
+			Username:     share.assignment.Username,
+			OldStartDate: dates.Format(share.oldStart, format),
+			NewStartDate: dates.Format(share.start, format),
+			OldEndDate:   dates.Format(share.assignment.End_Date.In(share.loc), format),
+			NewEndDate:   dates.Format(share.end, format),
+		}
+		simulated.Changed = !share.assignment.End_Date.Equal(share.end) || !share.oldStart.Equal(share.start)
+		if simulated.Changed {
+			result.Changed++
+		}
+		result.Assignments = append(result.Assignments, simulated)
+	}
+	return result, nil
+}
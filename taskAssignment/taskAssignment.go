@@ -6,38 +6,35 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
 	"github.com/saran-crayonte/task/models"
 )
 
 // CreateTaskAssignment handles creating a new task assignment
 //
 //	@Summary		Create a new task assignment
-//	@Description	Create a new task assignment with provided details
+//	@Description	Create a new task assignment with provided details. A task can be shared by several assignees; minutes (or whole hours) is this assignee's share of the estimate (default: all of it not yet shared out) and the task finishes when the last share does.
 //	@Tags			Task Assignment
 //	@Accept			json
 //	@Produce		json
 //
 //	@Security		ApiKeyAuth
-//	@Param			token			header		string					true	"API Key"
+//	@Param			token			header		string				true	"API Key"
 //
-//	@Param			taskAssignment	body		models.TaskAssignment	true	"Task assignment details"
-//	@Success		201				{object}	string					"Task assignment created successfully"
-//	@Failure		400				{object}	string					"Invalid request payload"
-//	@Failure		409				{object}	string					"Username doesn't exist / Task not found / Task is already assigned"
+//	@Param			taskAssignment	body		AssignmentRequest	true	"Task assignment details"
+//	@Param			mode			query		string				false	"Scheduling mode: parallel (default), queue after the user's existing work, reject overlaps, or backward from endDate to the latest start"	Enums(parallel, queue, reject, backward)
+//	@Param			dateFormat		query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		201				{object}	string				"Task assignment created successfully"
+//	@Failure		400				{object}	string				"Invalid request payload"
+//	@Failure		409				{object}	string				"Username doesn't exist / Task not found / Task is already assigned to this user / Task is already fully assigned / Overlaps existing assignments"
 //	@Router			/api/v2/taskAssignment [post]
 func CreateTaskAssignment() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		taskAssignment := new(models.TaskAssignment)
+		taskAssignment := new(AssignmentRequest)
 		if err := json.Unmarshal(c.Body(), &taskAssignment); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
 		}
 
-		var checkAlreadyAssigned models.TaskAssignment
-		database.DB.Where("task_id=?", taskAssignment.TaskID).First(&checkAlreadyAssigned)
-		if checkAlreadyAssigned.ID != 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Task is already assigned to somebody"})
-		}
-
 		var existingUser models.User
 		database.DB.Where("username=?", taskAssignment.Username).First(&existingUser)
 		if len(existingUser.Username) == 0 {
@@ -50,16 +47,49 @@ func CreateTaskAssignment() fiber.Handler {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Task not found"})
 		}
 
-		estimatedHours := existingTask.EstimatedHours
-		layout := "2006-01-02 3:04 PM"
-		startDate, err := time.Parse(layout, taskAssignment.Start_Date)
+		var checkAlreadyAssigned models.TaskAssignment
+		database.DB.Where("task_id=? AND username=?", taskAssignment.TaskID, taskAssignment.Username).First(&checkAlreadyAssigned)
+		if checkAlreadyAssigned.ID != 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Task is already assigned to this user"})
+		}
+
+		// without a share the assignee takes all of the task not yet shared out
+		remaining := UnassignedMinutes(existingTask, 0)
+		if remaining == 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Task is already fully assigned"})
+		}
+		minutes := RequestedMinutes(taskAssignment.Hours, taskAssignment.Minutes)
+		if minutes == 0 {
+			minutes = remaining
+		}
+		if minutes < 0 || minutes > remaining {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "share must be between 1 minute and the task's unassigned time", "unassignedMinutes": remaining, "unassignedHours": remaining / 60})
+		}
+
+		if _, err := LoadLocation(taskAssignment.Timezone); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		startDate, result, conflicts, err := scheduleAssignment(*taskAssignment, time.Duration(minutes)*time.Minute, c.Query("mode", ModeParallel))
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if len(conflicts) > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Assignment overlaps the user's existing assignments", "conflicts": conflicts})
 		}
-		result := CalculateEndDate(startDate, estimatedHours)
-		taskAssignment.Start_Date = startDate.Format("2006-01-02 3:04 PM")
-		taskAssignment.End_Date = result.Format("2006-01-02 3:04 PM")
-		database.DB.Create(taskAssignment)
+		assignment := models.TaskAssignment{
+			Username:   taskAssignment.Username,
+			TaskID:     taskAssignment.TaskID,
+			Start_Date: startDate,
+			End_Date:   result,
+			Timezone:   taskAssignment.Timezone,
+			Hours:      WholeHours(minutes),
+			Minutes:    minutes,
+		}
+		database.DB.Create(&assignment)
+		PropagateDependencies(assignment.TaskID)
+		_, taskEnd, _ := TaskSpan(assignment.TaskID)
+		format := dates.ResponseFormat(c)
 		type UserResponse struct {
 			Message      string `json:"message"`
 			AssignmentID string `json:"TaskAssignmentID"`
@@ -67,54 +97,118 @@ func CreateTaskAssignment() fiber.Handler {
 			TaskID       string `json:"taskID"`
 			StartDate    string `json:"startDate"`
 			EndDate      string `json:"EndDate"`
+			Timezone     string `json:"timezone"`
+			Hours        int    `json:"hours"`
+			Minutes      int    `json:"minutes"`
+			TaskEndDate  string `json:"taskEndDate"`
 		}
 		return c.Status(fiber.StatusCreated).JSON(UserResponse{
 			Message:      "Task Assignment created successfully",
-			AssignmentID: string(rune(taskAssignment.ID)),
-			Username:     taskAssignment.Username,
-			TaskID:       string(rune(taskAssignment.TaskID)),
-			StartDate:    taskAssignment.Start_Date,
-			EndDate:      taskAssignment.End_Date,
+			AssignmentID: string(rune(assignment.ID)),
+			Username:     assignment.Username,
+			TaskID:       string(rune(assignment.TaskID)),
+			StartDate:    dates.Format(startDate, format),
+			EndDate:      dates.Format(result, format),
+			Timezone:     AssignmentLocation(assignment).String(),
+			Hours:        assignment.Hours,
+			Minutes:      assignment.Minutes,
+			TaskEndDate:  dates.Format(taskEnd.In(startDate.Location()), format),
 		})
 	}
 }
 
-func CalculateEndDate(startDate time.Time, estimatedHours int) time.Time {
-	//workingHoursPerDay := 8
-	endDate := startDate
-	remainingHours := estimatedHours
+// CalculateEndDate returns the time at which estimate of work started at
+// startDate is finished according to the active working calendar
+func CalculateEndDate(startDate time.Time, estimate time.Duration) time.Time {
+	return ActiveWorkingCalendar().EndDate(startDate, estimate)
+}
 
-	for remainingHours > 0 {
+// CalculateUserEndDate is CalculateEndDate using the assignee's own working
+// schedule; working hours are applied in the location of startDate
+func CalculateUserEndDate(username string, startDate time.Time, estimate time.Duration) time.Time {
+	return UserWorkingCalendar(username).EndDate(startDate, estimate)
+}
 
-		if endDate.Weekday() == time.Saturday || endDate.Weekday() == time.Sunday || isHoliday(endDate) {
-			endDate = endDate.AddDate(0, 0, 1)
-			continue
-		}
+// CalculateStartDate returns the latest time at which estimate of work can
+// start to be finished by endDate according to the active working calendar
+func CalculateStartDate(endDate time.Time, estimate time.Duration) time.Time {
+	return ActiveWorkingCalendar().StartDate(endDate, estimate)
+}
 
-		if endDate.Hour() == 12 {
-			endDate = endDate.Add(time.Hour)
-			continue
-		}
+// CalculateUserStartDate is CalculateStartDate using the assignee's own
+// working schedule; working hours are applied in the location of endDate
+func CalculateUserStartDate(username string, endDate time.Time, estimate time.Duration) time.Time {
+	return UserWorkingCalendar(username).StartDate(endDate, estimate)
+}
 
-		if endDate.Hour() >= 18 {
-			endDate = endDate.AddDate(0, 0, 1).Truncate(24 * time.Hour).Add(9 * time.Hour)
-			continue
-		}
+// CalculateWorkingHours returns the working time between two instants
+// according to the active working calendar; it is negative when to is before
+// from. Working hours are applied in the location of from.
+func CalculateWorkingHours(from, to time.Time) time.Duration {
+	return ActiveWorkingCalendar().workingTimeBetween(from, to)
+}
 
-		remainingHours--
-		endDate = endDate.Add(time.Hour)
-	}
+// CalculateUserWorkingHours is CalculateWorkingHours using the user's own
+// working schedule, holiday calendar and leave
+func CalculateUserWorkingHours(username string, from, to time.Time) time.Duration {
+	return UserWorkingCalendar(username).workingTimeBetween(from, to)
+}
 
-	if endDate.Hour() > 18 {
-		endDate = endDate.AddDate(0, 0, 1).Truncate(24 * time.Hour).Add(9 * time.Hour)
+// EndDate walks the calendar a day at a time, consuming whole working
+// windows at once and skipping breaks, time outside the working day, weekend
+// days and closed holiday hours
+func (cal *WorkingCalendar) EndDate(startDate time.Time, estimate time.Duration) time.Time {
+	endDate := startDate
+	remaining := estimate
+
+	for day := startDate; remaining > 0; day = cal.nextDayStart(day) {
+		for _, w := range cal.dayWindows(day) {
+			windowStart, windowEnd := clock(day, w.start), clock(day, w.end)
+			if !endDate.Before(windowEnd) {
+				continue
+			}
+			if windowStart.After(endDate) {
+				endDate = windowStart
+			}
+			if left := windowEnd.Sub(endDate); remaining > left {
+				remaining -= left
+				endDate = windowEnd
+				continue
+			}
+			return endDate.Add(remaining)
+		}
 	}
 
 	return endDate
 }
-func isHoliday(date time.Time) bool {
-	holiday := new(models.Holiday)
-	database.DB.Where("holiday_date = ?", date.Format("2006-01-02")).First(&holiday)
-	return holiday.ID != 0
+
+// StartDate is the reverse of EndDate: it walks the calendar back from
+// endDate a day at a time and returns the latest start that finishes the work
+// by endDate
+func (cal *WorkingCalendar) StartDate(endDate time.Time, estimate time.Duration) time.Time {
+	startDate := endDate
+	remaining := estimate
+
+	for day := endDate; remaining > 0; day = clock(day, 0).AddDate(0, 0, -1) {
+		windows := cal.dayWindows(day)
+		for i := len(windows) - 1; i >= 0; i-- {
+			windowStart, windowEnd := clock(day, windows[i].start), clock(day, windows[i].end)
+			if !windowStart.Before(startDate) {
+				continue
+			}
+			if windowEnd.Before(startDate) {
+				startDate = windowEnd
+			}
+			if left := startDate.Sub(windowStart); remaining > left {
+				remaining -= left
+				startDate = windowStart
+				continue
+			}
+			return startDate.Add(-remaining)
+		}
+	}
+
+	return startDate
 }
 
 // GetTaskAssignment handles retrieving a task assignment by ID
@@ -126,12 +220,13 @@ func isHoliday(date time.Time) bool {
 //	@Produce		json
 //
 //	@Security		ApiKeyAuth
-//	@Param			token	header		string					true	"API Key"
+//	@Param			token		header		string					true	"API Key"
 //
-//	@Param			id		path		int						true	"Task Assignment ID"
-//	@Success		200		{object}	models.TaskAssignment	"Task assignment retrieved successfully"
-//	@Failure		400		{object}	string					"Invalid request payload"
-//	@Failure		404		{object}	string					"Task assignment not found"
+//	@Param			id			path		int						true	"Task Assignment ID"
+//	@Param			dateFormat	query		string					false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	models.TaskAssignment	"Task assignment retrieved successfully"
+//	@Failure		400			{object}	string					"Invalid request payload"
+//	@Failure		404			{object}	string					"Task assignment not found"
 //	@Router			/api/v2/taskAssignment/{id} [get]
 func GetTaskAssignment() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -147,29 +242,34 @@ func GetTaskAssignment() fiber.Handler {
 		if newTaskAssignment.ID == 0 {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Task Assignment ID not found"})
 		}
-		return c.Status(fiber.StatusOK).JSON(newTaskAssignment)
+		if dates.ResponseFormat(c) == dates.FormatLegacy {
+			return c.Status(fiber.StatusOK).JSON(LegacyAssignment(newTaskAssignment))
+		}
+		return c.Status(fiber.StatusOK).JSON(LocalAssignment(newTaskAssignment))
 	}
 }
 
 // UpdateTaskAssignment handles updating a task assignment by ID
 //
 //	@Summary		Update a task assignment by ID
-//	@Description	Update an existing task assignment by its ID
+//	@Description	Update an existing task assignment by its ID. Assignments of dependent tasks that would now start before it ends are moved later; they are not moved earlier when it ends earlier.
 //	@Tags			Task Assignment
 //	@Accept			json
 //	@Produce		json
 //
 //	@Security		ApiKeyAuth
-//	@Param			token			header		string					true	"API Key"
+//	@Param			token			header		string				true	"API Key"
 //
-//	@Param			taskAssignment	body		models.TaskAssignment	true	"Updated task assignment details"
-//	@Success		200				{object}	string					"Task assignment updated successfully"
-//	@Failure		400				{object}	string					"Invalid request payload"
-//	@Failure		404				{object}	string					"Username doesn't exist / Task not found / Task assignment not found"
+//	@Param			taskAssignment	body		AssignmentRequest	true	"Updated task assignment details"
+//	@Param			mode			query		string				false	"Scheduling mode: parallel (default), queue after the user's existing work, reject overlaps, or backward from endDate to the latest start"	Enums(parallel, queue, reject, backward)
+//	@Success		200				{object}	string				"Task assignment updated successfully"
+//	@Failure		400				{object}	string				"Invalid request payload"
+//	@Failure		404				{object}	string				"Username doesn't exist / Task not found / Task assignment not found"
+//	@Failure		409				{object}	string				"Task is already assigned to this user / Overlaps existing assignments"
 //	@Router			/api/v2/taskAssignment/{id} [put]
 func UpdateTaskAssignment() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		taskAssignment := new(models.TaskAssignment)
+		taskAssignment := new(AssignmentRequest)
 		if err := json.Unmarshal(c.Body(), &taskAssignment); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
 		}
@@ -192,22 +292,46 @@ func UpdateTaskAssignment() fiber.Handler {
 		}
 
 		var checkAlreadyAssigned models.TaskAssignment
-		database.DB.Where("task_id=?", taskAssignment.TaskID).First(&checkAlreadyAssigned)
-		if checkAlreadyAssigned.ID != 0 && checkAlreadyAssigned.ID != taskAssignment.ID {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Task is already assigned to somebody"})
+		database.DB.Where("task_id=? AND username=? AND id<>?", taskAssignment.TaskID, taskAssignment.Username, taskAssignment.ID).First(&checkAlreadyAssigned)
+		if checkAlreadyAssigned.ID != 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Task is already assigned to this user"})
 		}
 
-		estimatedHours := existingTask.EstimatedHours
-		layout := "2006-01-02 3:04 PM"
-		startDate, err := time.Parse(layout, taskAssignment.Start_Date)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+		// without a share it is kept, or on a move to another task the
+		// assignee takes all of it not yet shared out
+		remaining := UnassignedMinutes(existingTask, taskAssignment.ID)
+		minutes := RequestedMinutes(taskAssignment.Hours, taskAssignment.Minutes)
+		if minutes == 0 {
+			minutes = remaining
+			if existingTaskAssignment.TaskID == taskAssignment.TaskID {
+				minutes = ShareMinutes(existingTaskAssignment, EstimateMinutes(existingTask))
+			}
+		}
+		if minutes <= 0 || minutes > remaining {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "share must be between 1 minute and the task's unassigned time", "unassignedMinutes": remaining, "unassignedHours": remaining / 60})
 		}
-		result := CalculateEndDate(startDate, estimatedHours)
-		taskAssignment.Start_Date = startDate.Format("2006-01-02 3:04 PM")
-		taskAssignment.End_Date = result.Format("2006-01-02 3:04 PM")
 
-		database.DB.Model(&existingTaskAssignment).Updates(taskAssignment)
+		if _, err := LoadLocation(taskAssignment.Timezone); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		startDate, result, conflicts, err := scheduleAssignment(*taskAssignment, time.Duration(minutes)*time.Minute, c.Query("mode", ModeParallel))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if len(conflicts) > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Assignment overlaps the user's existing assignments", "conflicts": conflicts})
+		}
+		database.DB.Model(&existingTaskAssignment).Updates(models.TaskAssignment{
+			Username:   taskAssignment.Username,
+			TaskID:     taskAssignment.TaskID,
+			Start_Date: startDate,
+			End_Date:   result,
+			Timezone:   taskAssignment.Timezone,
+			Hours:      WholeHours(minutes),
+			Minutes:    minutes,
+		})
+		PropagateDependencies(taskAssignment.TaskID)
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Task Assignment Updated successfully"})
 	}
 }
@@ -260,14 +384,25 @@ func DeleteTaskAssignment() fiber.Handler {
 //	@Produce		json
 //
 //	@Security		ApiKeyAuth
-//	@Param			token	header		string					true	"API Key"
+//	@Param			token		header		string					true	"API Key"
 //
-//	@Success		200		{object}	models.TaskAssignment	"Task Assignment retrieved successfully"
+//	@Param			dateFormat	query		string					false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	models.TaskAssignment	"Task Assignment retrieved successfully"
 //	@Router			/api/v2/taskAssignment [get]
 func DisplayAllTaskAssignments() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var taskAssignment []models.TaskAssignment
 		database.DB.Find(&taskAssignment)
+		if dates.ResponseFormat(c) == dates.FormatLegacy {
+			legacy := make([]LegacyTaskAssignment, len(taskAssignment))
+			for i, assignment := range taskAssignment {
+				legacy[i] = LegacyAssignment(assignment)
+			}
+			return c.Status(fiber.StatusOK).JSON(legacy)
+		}
+		for i, assignment := range taskAssignment {
+			taskAssignment[i] = LocalAssignment(assignment)
+		}
 		return c.Status(fiber.StatusOK).JSON(taskAssignment)
 	}
 }
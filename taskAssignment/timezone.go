@@ -0,0 +1,95 @@
+package taskAssignment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// LoadLocation resolves an IANA timezone name; an empty name means UTC
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("invalid timezone " + name)
+	}
+	return loc, nil
+}
+
+// UserLocation returns the timezone from the user's schedule, or UTC
+func UserLocation(username string) *time.Location {
+	var schedule models.UserSchedule
+	database.DB.Where("username = ?", username).First(&schedule)
+	loc, err := LoadLocation(schedule.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// AssignmentLocation returns the timezone an assignment is scheduled in: its
+// own timezone when set, otherwise the assignee's
+func AssignmentLocation(assignment models.TaskAssignment) *time.Location {
+	if assignment.Timezone != "" {
+		if loc, err := LoadLocation(assignment.Timezone); err == nil {
+			return loc
+		}
+	}
+	return UserLocation(assignment.Username)
+}
+
+// AssignmentRequest is the body of a new or updated task assignment; dates
+// are given in any layout dates.Parse accepts, in the assignment's timezone
+// when they carry no offset
+type AssignmentRequest struct {
+	ID         uint   `json:"id"`
+	Username   string `json:"username"`
+	TaskID     uint   `json:"taskid"`
+	Start_Date string `json:"startDate"`
+	End_Date   string `json:"endDate"`
+	Timezone   string `json:"timezone"`
+	Hours      int    `json:"hours"`
+	Minutes    int    `json:"minutes"`
+}
+
+// LegacyTaskAssignment is a task assignment with its dates as strings in
+// dates.LegacyLayout, local to its timezone
+type LegacyTaskAssignment struct {
+	ID         uint   `json:"id"`
+	Username   string `json:"username"`
+	TaskID     uint   `json:"taskid"`
+	Start_Date string `json:"startDate"`
+	End_Date   string `json:"endDate"`
+	Timezone   string `json:"timezone"`
+	Hours      int    `json:"hours"`
+	Minutes    int    `json:"minutes"`
+}
+
+// LocalAssignment returns the assignment with its dates in its own timezone
+func LocalAssignment(assignment models.TaskAssignment) models.TaskAssignment {
+	loc := AssignmentLocation(assignment)
+	assignment.Start_Date = assignment.Start_Date.In(loc)
+	assignment.End_Date = assignment.End_Date.In(loc)
+	return assignment
+}
+
+// LegacyAssignment returns the assignment with its dates formatted in
+// dates.LegacyLayout in its own timezone
+func LegacyAssignment(assignment models.TaskAssignment) LegacyTaskAssignment {
+	assignment = LocalAssignment(assignment)
+	return LegacyTaskAssignment{
+		ID:         assignment.ID,
+		Username:   assignment.Username,
+		TaskID:     assignment.TaskID,
+		Start_Date: dates.Format(assignment.Start_Date, dates.FormatLegacy),
+		End_Date:   dates.Format(assignment.End_Date, dates.FormatLegacy),
+		Timezone:   assignment.Timezone,
+		Hours:      assignment.Hours,
+		Minutes:    assignment.Minutes,
+	}
+}
@@ -0,0 +1,92 @@
+package taskAssignment
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+)
+
+// WorkingHoursRequest asks for the working time between two instants
+type WorkingHoursRequest struct {
+	Username  string `json:"username"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Timezone  string `json:"timezone"`
+}
+
+// WorkingHoursResult is the working time between two instants
+type WorkingHoursResult struct {
+	Username  string  `json:"username"`
+	StartDate string  `json:"startDate"`
+	EndDate   string  `json:"endDate"`
+	Hours     float64 `json:"hours"`
+	Minutes   int     `json:"minutes"`
+}
+
+// WorkingHours handles counting the working hours between two timestamps
+//
+//	@Summary		Working hours between two timestamps
+//	@Description	Count the working time between startDate and endDate, skipping time outside the working day, breaks, weekend days and holidays. Uses the user's working schedule, holiday calendar and leave when username is given, otherwise the active working calendar. The result is negative when endDate is before startDate.
+//	@Tags			Task Assignment
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			request		body		WorkingHoursRequest	true	"Timestamps and optional user"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	WorkingHoursResult	"Working hours computed successfully"
+//	@Failure		400			{object}	string				"Invalid request payload / invalid date time format"
+//	@Failure		404			{object}	string				"Username doesn't exist"
+//	@Router			/api/v2/taskAssignment/workingHours [post]
+func WorkingHours() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := new(WorkingHoursRequest)
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if request.Username != "" {
+			var existingUser models.User
+			database.DB.Where("username=?", request.Username).First(&existingUser)
+			if len(existingUser.Username) == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Username doesn't exists"})
+			}
+		}
+
+		loc := UserLocation(request.Username)
+		if request.Timezone != "" {
+			var err error
+			if loc, err = LoadLocation(request.Timezone); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		from, err := dates.Parse(request.StartDate, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+		}
+		to, err := dates.Parse(request.EndDate, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date time format"})
+		}
+		from, to = from.In(loc), to.In(loc)
+
+		duration := CalculateWorkingHours(from, to)
+		if request.Username != "" {
+			duration = CalculateUserWorkingHours(request.Username, from, to)
+		}
+		format := dates.ResponseFormat(c)
+		return c.Status(fiber.StatusOK).JSON(WorkingHoursResult{
+			Username:  request.Username,
+			StartDate: dates.Format(from, format),
+			EndDate:   dates.Format(to, format),
+			Hours:     math.Round(duration.Hours()*100) / 100,
+			Minutes:   int(duration / time.Minute),
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package timeEntry
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Conflicts found while writing an entry under the user's lock
+var (
+	errTimerRunning = errors.New("A timer is already running")
+	errOverlap      = errors.New("Overlaps an existing time entry")
+)
+
+// TimeEntryRequest is the body of a timer start or a manually logged entry;
+// times are given in any layout dates.Parse accepts, in the user's timezone
+// when they carry no offset
+type TimeEntryRequest struct {
+	ID           uint   `json:"id"`
+	AssignmentID uint   `json:"assignmentID"`
+	StartTime    string `json:"startTime"`
+	EndTime      string `json:"endTime"`
+	Note         string `json:"note"`
+}
+
+// TimeEntryResponse is a time entry with its times in the user's timezone;
+// a running timer has no end time and counts up to now
+type TimeEntryResponse struct {
+	ID           uint   `json:"id"`
+	Username     string `json:"username"`
+	AssignmentID uint   `json:"assignmentID"`
+	TaskID       uint   `json:"taskid"`
+	StartTime    string `json:"startTime"`
+	EndTime      string `json:"endTime"`
+	Running      bool   `json:"running"`
+	Minutes      int    `json:"minutes"`
+	Note         string `json:"note"`
+}
+
+// StartTimer handles starting a timer against one of the user's assignments
+//
+//	@Summary		Start a timer
+//	@Description	Start a timer for the authenticated user against one of their task assignments. Only one timer can run at a time and it must not overlap logged entries.
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			timeEntry	body		TimeEntryRequest	true	"Assignment and note"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		201			{object}	TimeEntryResponse	"Timer started successfully"
+//	@Failure		400			{object}	string				"Invalid request payload"
+//	@Failure		403			{object}	string				"Task assignment belongs to another user"
+//	@Failure		404			{object}	string				"Task assignment not found"
+//	@Failure		409			{object}	string				"A timer is already running / Overlaps an existing time entry"
+//	@Failure		500			{object}	string				"Failed to start timer"
+//	@Router			/api/v2/timeEntry/start [post]
+func StartTimer() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		username, ok := c.Locals("username").(string)
+		if !ok {
+			return fiber.ErrUnauthorized
+		}
+		req := new(TimeEntryRequest)
+		if err := json.Unmarshal(c.Body(), &req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		assignment, status, err := ownAssignment(req.AssignmentID, username)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		entry := models.TimeEntry{
+			Username:     username,
+			AssignmentID: assignment.ID,
+			TaskID:       assignment.TaskID,
+			Note:         req.Note,
+		}
+		err = withUserLock(username, func(tx *gorm.DB) error {
+			if runningTimer(tx, username).ID != 0 {
+				return errTimerRunning
+			}
+			entry.StartTime = time.Now()
+			if overlapsEntry(tx, entry) {
+				return errOverlap
+			}
+			return tx.Create(&entry).Error
+		})
+		if err != nil {
+			return writeError(c, err, "Failed to start timer")
+		}
+		return c.Status(fiber.StatusCreated).JSON(entryResponse(entry, dates.ResponseFormat(c)))
+	}
+}
+
+// StopTimer handles stopping the user's running timer
+//
+//	@Summary		Stop the running timer
+//	@Description	Stop the authenticated user's running timer, logging the time since it started
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	TimeEntryResponse	"Timer stopped successfully"
+//	@Failure		404			{object}	string				"No timer is running"
+//	@Failure		500			{object}	string				"Failed to stop timer"
+//	@Router			/api/v2/timeEntry/stop [post]
+func StopTimer() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		username, ok := c.Locals("username").(string)
+		if !ok {
+			return fiber.ErrUnauthorized
+		}
+		entry := runningTimer(database.DB, username)
+		if entry.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No timer is running"})
+		}
+		now := time.Now()
+		if now.Before(entry.StartTime) {
+			now = entry.StartTime
+		}
+		entry.EndTime = &now
+		if err := database.DB.Model(&entry).Update("end_time", now).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to stop timer"})
+		}
+		return c.Status(fiber.StatusOK).JSON(entryResponse(entry, dates.ResponseFormat(c)))
+	}
+}
+
+// CreateTimeEntry handles logging a manual time entry
+//
+//	@Summary		Log a time entry
+//	@Description	Log time the authenticated user spent on one of their task assignments from startTime to endTime. Entries of a user must not overlap.
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			timeEntry	body		TimeEntryRequest	true	"Time entry details"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		201			{object}	TimeEntryResponse	"Time entry created successfully"
+//	@Failure		400			{object}	string				"Invalid request payload / invalid date time format / end time must be after start time"
+//	@Failure		403			{object}	string				"Task assignment belongs to another user"
+//	@Failure		404			{object}	string				"Task assignment not found"
+//	@Failure		409			{object}	string				"Overlaps an existing time entry"
+//	@Failure		500			{object}	string				"Failed to create time entry"
+//	@Router			/api/v2/timeEntry [post]
+func CreateTimeEntry() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		username, ok := c.Locals("username").(string)
+		if !ok {
+			return fiber.ErrUnauthorized
+		}
+		req := new(TimeEntryRequest)
+		if err := json.Unmarshal(c.Body(), &req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		assignment, status, err := ownAssignment(req.AssignmentID, username)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		entry := models.TimeEntry{
+			Username:     username,
+			AssignmentID: assignment.ID,
+			TaskID:       assignment.TaskID,
+			Note:         req.Note,
+		}
+		if err := entryTimes(&entry, *req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if entry.EndTime == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "end time is required"})
+		}
+		err = withUserLock(username, func(tx *gorm.DB) error {
+			if overlapsEntry(tx, entry) {
+				return errOverlap
+			}
+			return tx.Create(&entry).Error
+		})
+		if err != nil {
+			return writeError(c, err, "Failed to create time entry")
+		}
+		return c.Status(fiber.StatusCreated).JSON(entryResponse(entry, dates.ResponseFormat(c)))
+	}
+}
+
+// GetTimeEntry handles retrieving a time entry by ID
+//
+//	@Summary		Get a time entry by ID
+//	@Description	Retrieve a time entry by its ID
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			id			path		int					true	"Time entry ID"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{object}	TimeEntryResponse	"Time entry retrieved successfully"
+//	@Failure		400			{object}	string				"Invalid request payload"
+//	@Failure		404			{object}	string				"Time entry not found"
+//	@Router			/api/v2/timeEntry/{id} [get]
+func GetTimeEntry() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var entry models.TimeEntry
+		database.DB.Where("id=?", b.ID).First(&entry)
+		if entry.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Time entry not found"})
+		}
+		return c.Status(fiber.StatusOK).JSON(entryResponse(entry, dates.ResponseFormat(c)))
+	}
+}
+
+// UpdateTimeEntry handles correcting a time entry by ID
+//
+//	@Summary		Update a time entry by ID
+//	@Description	Correct the times or note of one of the authenticated user's entries; times left empty are kept. Entries of a user must not overlap.
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			timeEntry	body		TimeEntryRequest	true	"Updated time entry details"
+//	@Success		200			{object}	string				"Time entry updated successfully"
+//	@Failure		400			{object}	string				"Invalid request payload / invalid date time format / end time must be after start time"
+//	@Failure		403			{object}	string				"Time entry belongs to another user"
+//	@Failure		404			{object}	string				"Time entry not found"
+//	@Failure		409			{object}	string				"Overlaps an existing time entry"
+//	@Failure		500			{object}	string				"Failed to update time entry"
+//	@Router			/api/v2/timeEntry/{id} [put]
+func UpdateTimeEntry() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		username, ok := c.Locals("username").(string)
+		if !ok {
+			return fiber.ErrUnauthorized
+		}
+		req := new(TimeEntryRequest)
+		if err := json.Unmarshal(c.Body(), &req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var entry models.TimeEntry
+		database.DB.Where("id=?", req.ID).First(&entry)
+		if entry.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Time entry not found"})
+		}
+		if entry.Username != username {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Time entry belongs to another user"})
+		}
+		if err := entryTimes(&entry, *req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if req.Note != "" {
+			entry.Note = req.Note
+		}
+		err := withUserLock(username, func(tx *gorm.DB) error {
+			if overlapsEntry(tx, entry) {
+				return errOverlap
+			}
+			return tx.Save(&entry).Error
+		})
+		if err != nil {
+			return writeError(c, err, "Failed to update time entry")
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Time entry updated successfully"})
+	}
+}
+
+// DeleteTimeEntry handles deleting a time entry by ID
+//
+//	@Summary		Delete a time entry by ID
+//	@Description	Delete one of the authenticated user's time entries
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string	true	"API Key"
+//
+//	@Param			id		path		int		true	"Time entry ID"
+//	@Success		200		{object}	string	"Time entry deleted successfully"
+//	@Failure		400		{object}	string	"Invalid request payload"
+//	@Failure		403		{object}	string	"Time entry belongs to another user"
+//	@Failure		404		{object}	string	"Time entry not found"
+//	@Failure		500		{object}	string	"Failed to delete time entry"
+//	@Router			/api/v2/timeEntry/{id} [delete]
+func DeleteTimeEntry() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		username, ok := c.Locals("username").(string)
+		if !ok {
+			return fiber.ErrUnauthorized
+		}
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var entry models.TimeEntry
+		database.DB.Where("id=?", b.ID).First(&entry)
+		if entry.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Time entry not found"})
+		}
+		if entry.Username != username {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Time entry belongs to another user"})
+		}
+		if err := database.DB.Delete(&entry).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete time entry"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Time entry deleted successfully",
+		})
+	}
+}
+
+// DisplayAllTimeEntries handles retrieving all time entries
+//
+//	@Summary		Get all time entries
+//	@Description	Retrieve all time entries, optionally of one user or task, oldest first
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			username	query		string				false	"Only list entries of this user"
+//	@Param			taskid		query		int					false	"Only list entries against this task"
+//	@Param			dateFormat	query		string				false	"Date format of the response, also read from the X-Date-Format header"	Enums(rfc3339, legacy)
+//	@Success		200			{array}		TimeEntryResponse	"Time entries retrieved successfully"
+//	@Router			/api/v2/timeEntry [get]
+func DisplayAllTimeEntries() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var entries []models.TimeEntry
+		filteredEntries(c).Order("start_time").Find(&entries)
+		format := dates.ResponseFormat(c)
+		response := make([]TimeEntryResponse, 0, len(entries))
+		for _, entry := range entries {
+			response = append(response, entryResponse(entry, format))
+		}
+		return c.Status(fiber.StatusOK).JSON(response)
+	}
+}
+
+// ownAssignment looks up an assignment the user may log time against,
+// returning the status to answer with when they may not
+func ownAssignment(id uint, username string) (models.TaskAssignment, int, error) {
+	var assignment models.TaskAssignment
+	database.DB.Where("id=?", id).First(&assignment)
+	if assignment.ID == 0 {
+		return assignment, fiber.StatusNotFound, errors.New("Task assignment not found")
+	}
+	if assignment.Username != username {
+		return assignment, fiber.StatusForbidden, errors.New("Task assignment belongs to another user")
+	}
+	return assignment, fiber.StatusOK, nil
+}
+
+// withUserLock runs write in a transaction holding the lock of the user's
+// row, so the timer and overlap checks of concurrent requests of one user
+// cannot both pass
+func withUserLock(username string, write func(tx *gorm.DB) error) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("username=?", username).First(&user).Error; err != nil {
+			return err
+		}
+		return write(tx)
+	})
+}
+
+// writeError answers a failed entry write: a conflict with 409, anything
+// else with 500 and failure
+func writeError(c *fiber.Ctx, err error, failure string) error {
+	if errors.Is(err, errTimerRunning) || errors.Is(err, errOverlap) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": failure})
+}
+
+// runningTimer returns the user's entry without an end time, if any
+func runningTimer(db *gorm.DB, username string) models.TimeEntry {
+	var entry models.TimeEntry
+	db.Where("username=? AND end_time IS NULL", username).First(&entry)
+	return entry
+}
+
+// overlapsEntry reports whether another entry of the same user overlaps; an
+// entry without an end time is open ended
+func overlapsEntry(db *gorm.DB, entry models.TimeEntry) bool {
+	query := db.Where("username=? AND id<>? AND (end_time IS NULL OR end_time>?)", entry.Username, entry.ID, entry.StartTime)
+	if entry.EndTime != nil {
+		query = query.Where("start_time<?", *entry.EndTime)
+	}
+	var existing models.TimeEntry
+	query.First(&existing)
+	return existing.ID != 0
+}
+
+// entryTimes parses the start and end time given in the request into the
+// entry, keeping the entry's own times where none is given
+func entryTimes(entry *models.TimeEntry, req TimeEntryRequest) error {
+	loc := taskAssignment.UserLocation(entry.Username)
+	if req.StartTime != "" {
+		start, err := dates.Parse(req.StartTime, loc)
+		if err != nil {
+			return err
+		}
+		entry.StartTime = start
+	}
+	if req.EndTime != "" {
+		end, err := dates.Parse(req.EndTime, loc)
+		if err != nil {
+			return err
+		}
+		entry.EndTime = &end
+	}
+	if entry.StartTime.IsZero() {
+		return errors.New("start time is required")
+	}
+	if entry.EndTime != nil && !entry.EndTime.After(entry.StartTime) {
+		return errors.New("end time must be after start time")
+	}
+	now := time.Now()
+	if entry.StartTime.After(now) || entry.EndTime != nil && entry.EndTime.After(now) {
+		return errors.New("time entries must not be in the future")
+	}
+	return nil
+}
+
+// filteredEntries applies the username and taskid query filters
+func filteredEntries(c *fiber.Ctx) *gorm.DB {
+	query := database.DB
+	if username := c.Query("username"); username != "" {
+		query = query.Where("username=?", username)
+	}
+	if taskID, err := strconv.Atoi(c.Query("taskid")); err == nil {
+		query = query.Where("task_id=?", taskID)
+	}
+	return query
+}
+
+// entryResponse formats an entry in its user's timezone
+func entryResponse(entry models.TimeEntry, format string) TimeEntryResponse {
+	loc := taskAssignment.UserLocation(entry.Username)
+	response := TimeEntryResponse{
+		ID:           entry.ID,
+		Username:     entry.Username,
+		AssignmentID: entry.AssignmentID,
+		TaskID:       entry.TaskID,
+		StartTime:    dates.Format(entry.StartTime.In(loc), format),
+		Running:      entry.EndTime == nil,
+		Minutes:      durationMinutes(entryDuration(entry, time.Now())),
+		Note:         entry.Note,
+	}
+	if entry.EndTime != nil {
+		response.EndTime = dates.Format(entry.EndTime.In(loc), format)
+	}
+	return response
+}
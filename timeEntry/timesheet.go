@@ -0,0 +1,271 @@
+package timeEntry
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/dates"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+)
+
+// TimesheetDay is one day of a weekly timesheet with the time logged on it
+type TimesheetDay struct {
+	Date     string   `json:"date"`
+	Weekday  string   `json:"weekday"`
+	Minutes  int      `json:"minutes"`
+	Hours    float64  `json:"hours"`
+	Weekend  bool     `json:"weekend"`
+	Holiday  bool     `json:"holiday"`
+	Holidays []string `json:"holidays,omitempty"`
+	Leave    bool     `json:"leave"`
+}
+
+// TimesheetTask is the time logged against one task, per day from Monday
+type TimesheetTask struct {
+	TaskID       uint      `json:"taskid"`
+	Title        string    `json:"title"`
+	Minutes      []int     `json:"minutes"`
+	Hours        []float64 `json:"hours"`
+	TotalMinutes int       `json:"totalMinutes"`
+	TotalHours   float64   `json:"totalHours"`
+}
+
+// Timesheet is the time one user logged in one week, Monday to Sunday in the
+// user's timezone
+type Timesheet struct {
+	Username     string          `json:"username"`
+	WeekStart    string          `json:"weekStart"`
+	Days         []TimesheetDay  `json:"days"`
+	Tasks        []TimesheetTask `json:"tasks"`
+	TotalMinutes int             `json:"totalMinutes"`
+	TotalHours   float64         `json:"totalHours"`
+}
+
+// WeeklyTimesheet handles reporting the time logged per user and week
+//
+//	@Summary		Weekly timesheet
+//	@Description	Time logged per user and week, per task and per day, with weekends, holidays and leave flagged, as JSON or CSV (format=csv). Weeks run Monday to Sunday in each user's timezone; running timers count up to now.
+//	@Tags			Time Tracking
+//	@Produce		json
+//	@Produce		text/csv
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string		true	"API Key"
+//
+//	@Param			week		query		string		false	"Any day of the first week (YYYY-MM-DD, default today)"
+//	@Param			weeks		query		int			false	"Number of weeks (default 1, at most 53)"
+//	@Param			username	query		string		false	"Only report this user (default every user)"
+//	@Param			format		query		string		false	"Response format"	Enums(json, csv)
+//	@Success		200			{array}		Timesheet	"Timesheets retrieved successfully"
+//	@Failure		400			{object}	string		"invalid date format / Invalid number of weeks"
+//	@Failure		404			{object}	string		"Username doesn't exist"
+//	@Router			/api/v2/timeEntry/timesheet [get]
+func WeeklyTimesheet() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var week time.Time
+		if c.Query("week") != "" {
+			day, err := dates.ParseDay(c.Query("week"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date format"})
+			}
+			week = day
+		}
+		weeks := 1
+		if c.Query("weeks") != "" {
+			n, err := strconv.Atoi(c.Query("weeks"))
+			if err != nil || n < 1 || n > 53 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid number of weeks"})
+			}
+			weeks = n
+		}
+
+		var users []models.User
+		query := database.DB.Order("username")
+		if username := c.Query("username"); username != "" {
+			query = query.Where("username=?", username)
+		}
+		query.Find(&users)
+		if c.Query("username") != "" && len(users) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Username doesn't exists"})
+		}
+
+		now := time.Now()
+		titles := make(map[uint]string)
+		sheets := []Timesheet{}
+		for _, user := range users {
+			loc := taskAssignment.UserLocation(user.Username)
+			cal := taskAssignment.UserWorkingCalendar(user.Username)
+			day := week
+			if day.IsZero() {
+				day = now.In(loc)
+			}
+			first := weekStart(day, loc)
+			last := first.AddDate(0, 0, 7*weeks)
+
+			var entries []models.TimeEntry
+			database.DB.Where("username=? AND start_time<? AND (end_time IS NULL OR end_time>?)", user.Username, last, first).Find(&entries)
+			for start := first; start.Before(last); start = start.AddDate(0, 0, 7) {
+				sheet := buildTimesheet(user.Username, start, entries, now)
+				flagDays(&sheet, cal, start)
+				for i := range sheet.Tasks {
+					sheet.Tasks[i].Title = taskTitle(titles, sheet.Tasks[i].TaskID)
+				}
+				sheets = append(sheets, sheet)
+			}
+		}
+
+		if c.Query("format") == "csv" {
+			c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+			c.Set(fiber.HeaderContentDisposition, `attachment; filename="timesheet.csv"`)
+			return c.Status(fiber.StatusOK).SendString(timesheetCSV(sheets))
+		}
+		return c.Status(fiber.StatusOK).JSON(sheets)
+	}
+}
+
+// weekStart returns midnight of the Monday of the week containing the
+// calendar date of day, in loc
+func weekStart(day time.Time, loc *time.Location) time.Time {
+	monday := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	return monday.AddDate(0, 0, -(int(monday.Weekday())+6)%7)
+}
+
+// buildTimesheet splits the entries over the seven days from start, clipping
+// entries that cross midnight or the week's bounds
+func buildTimesheet(username string, start time.Time, entries []models.TimeEntry, now time.Time) Timesheet {
+	sheet := Timesheet{
+		Username:  username,
+		WeekStart: start.Format(dates.DayLayout),
+		Days:      make([]TimesheetDay, 7),
+		Tasks:     []TimesheetTask{},
+	}
+	logged := make(map[uint]*[7]time.Duration)
+	for _, entry := range entries {
+		end := now
+		if entry.EndTime != nil {
+			end = *entry.EndTime
+		}
+		for i := 0; i < 7; i++ {
+			dayStart, dayEnd := start.AddDate(0, 0, i), start.AddDate(0, 0, i+1)
+			from, to := entry.StartTime, end
+			if from.Before(dayStart) {
+				from = dayStart
+			}
+			if to.After(dayEnd) {
+				to = dayEnd
+			}
+			if !to.After(from) {
+				continue
+			}
+			if logged[entry.TaskID] == nil {
+				logged[entry.TaskID] = new([7]time.Duration)
+			}
+			logged[entry.TaskID][i] += to.Sub(from)
+		}
+	}
+
+	for i := range sheet.Days {
+		day := start.AddDate(0, 0, i)
+		sheet.Days[i].Date = day.Format(dates.DayLayout)
+		sheet.Days[i].Weekday = day.Weekday().String()
+	}
+	for taskID, days := range logged {
+		task := TimesheetTask{TaskID: taskID, Minutes: make([]int, 7), Hours: make([]float64, 7)}
+		for i, d := range days {
+			minutes := durationMinutes(d)
+			task.Minutes[i] = minutes
+			task.Hours[i] = minutesToHours(minutes)
+			task.TotalMinutes += minutes
+			sheet.Days[i].Minutes += minutes
+		}
+		task.TotalHours = minutesToHours(task.TotalMinutes)
+		sheet.TotalMinutes += task.TotalMinutes
+		sheet.Tasks = append(sheet.Tasks, task)
+	}
+	for i := range sheet.Days {
+		sheet.Days[i].Hours = minutesToHours(sheet.Days[i].Minutes)
+	}
+	sheet.TotalHours = minutesToHours(sheet.TotalMinutes)
+	sort.Slice(sheet.Tasks, func(i, j int) bool { return sheet.Tasks[i].TaskID < sheet.Tasks[j].TaskID })
+	return sheet
+}
+
+// flagDays marks the weekend, holiday and leave days of the sheet in the
+// user's working calendar
+func flagDays(sheet *Timesheet, cal *taskAssignment.WorkingCalendar, start time.Time) {
+	for i := range sheet.Days {
+		day := start.AddDate(0, 0, i)
+		sheet.Days[i].Weekend = cal.IsWeekend(day)
+		sheet.Days[i].Holidays = cal.Holidays(day)
+		sheet.Days[i].Holiday = len(sheet.Days[i].Holidays) > 0
+		sheet.Days[i].Leave = cal.OnLeave(day)
+	}
+}
+
+// taskTitle looks up a task's title once per request
+func taskTitle(titles map[uint]string, taskID uint) string {
+	title, ok := titles[taskID]
+	if !ok {
+		var findTask models.Task
+		database.DB.Where("id=?", taskID).First(&findTask)
+		title = findTask.Title
+		titles[taskID] = title
+	}
+	return title
+}
+
+// timesheetCSV writes a row of day flags, a row per task and a total row for
+// every sheet, with the hours of each day from Monday
+func timesheetCSV(sheets []Timesheet) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"username", "weekStart", "taskid", "title", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday", "total"})
+	for _, sheet := range sheets {
+		flags := []string{sheet.Username, sheet.WeekStart, "", "flags"}
+		for _, day := range sheet.Days {
+			flags = append(flags, dayFlags(day))
+		}
+		w.Write(append(flags, ""))
+		for _, task := range sheet.Tasks {
+			row := []string{sheet.Username, sheet.WeekStart, strconv.Itoa(int(task.TaskID)), task.Title}
+			for _, hours := range task.Hours {
+				row = append(row, formatHours(hours))
+			}
+			w.Write(append(row, formatHours(task.TotalHours)))
+		}
+		total := []string{sheet.Username, sheet.WeekStart, "", "total"}
+		for _, day := range sheet.Days {
+			total = append(total, formatHours(day.Hours))
+		}
+		w.Write(append(total, formatHours(sheet.TotalHours)))
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// dayFlags describes a day in the CSV flags row, e.g. "weekend; holiday: New Year"
+func dayFlags(day TimesheetDay) string {
+	var flags []string
+	if day.Weekend {
+		flags = append(flags, "weekend")
+	}
+	if day.Holiday {
+		flags = append(flags, "holiday: "+strings.Join(day.Holidays, ", "))
+	}
+	if day.Leave {
+		flags = append(flags, "leave")
+	}
+	return strings.Join(flags, "; ")
+}
+
+// formatHours writes hours without trailing zeros
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', -1, 64)
+}
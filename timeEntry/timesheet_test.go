@@ -0,0 +1,75 @@
+package timeEntry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saran-crayonte/task/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekStart(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	for _, day := range []int{25, 27, 31} {
+		start := weekStart(time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC), berlin)
+		assert.Equal(t, time.Date(2024, 3, 25, 0, 0, 0, 0, berlin), start, "March %d", day)
+	}
+}
+
+func TestBuildTimesheet(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	at := func(day, hour, minute int) *time.Time {
+		value := time.Date(2024, 3, day, hour, minute, 0, 0, berlin)
+		return &value
+	}
+	start := weekStart(*at(27, 0, 0), berlin)
+	entries := []models.TimeEntry{
+		// from the week before into Monday
+		{TaskID: 1, StartTime: *at(24, 23, 0), EndTime: at(25, 1, 30)},
+		{TaskID: 2, StartTime: *at(25, 9, 0), EndTime: at(25, 11, 15)},
+		// across midnight on Tuesday
+		{TaskID: 1, StartTime: *at(26, 22, 0), EndTime: at(27, 2, 0)},
+		// across the switch to summer time, two hours of wall clock but one elapsed
+		{TaskID: 2, StartTime: *at(31, 1, 30), EndTime: at(31, 3, 30)},
+		// a running timer
+		{TaskID: 1, StartTime: *at(29, 16, 0)},
+	}
+	sheet := buildTimesheet("tester", start, entries, *at(29, 16, 45))
+
+	assert.Equal(t, "2024-03-25", sheet.WeekStart)
+	assert.Equal(t, "Sunday", sheet.Days[6].Weekday)
+	assert.Equal(t, "2024-03-31", sheet.Days[6].Date)
+	if assert.Len(t, sheet.Tasks, 2) {
+		assert.Equal(t, []int{90, 120, 120, 0, 45, 0, 0}, sheet.Tasks[0].Minutes)
+		assert.Equal(t, 375, sheet.Tasks[0].TotalMinutes)
+		assert.Equal(t, []int{135, 0, 0, 0, 0, 0, 60}, sheet.Tasks[1].Minutes)
+		assert.Equal(t, 3.25, sheet.Tasks[1].TotalHours)
+	}
+	assert.Equal(t, 225, sheet.Days[0].Minutes)
+	assert.Equal(t, 3.75, sheet.Days[0].Hours)
+	assert.Equal(t, 570, sheet.TotalMinutes)
+}
+
+func TestTimesheetCSV(t *testing.T) {
+	sheet := Timesheet{
+		Username:  "tester",
+		WeekStart: "2024-03-25",
+		Days: []TimesheetDay{
+			{Hours: 2.5}, {}, {Holiday: true, Holidays: []string{"Founders, Day"}}, {}, {Leave: true}, {Weekend: true}, {Weekend: true, Holiday: true, Holidays: []string{"Easter"}, Hours: 1},
+		},
+		Tasks: []TimesheetTask{
+			{TaskID: 3, Title: "Design", Hours: []float64{2.5, 0, 0, 0, 0, 0, 1}, TotalHours: 3.5},
+		},
+		TotalHours: 3.5,
+	}
+	lines := strings.Split(strings.TrimSpace(timesheetCSV([]Timesheet{sheet})), "\n")
+	assert.Equal(t, []string{
+		"username,weekStart,taskid,title,monday,tuesday,wednesday,thursday,friday,saturday,sunday,total",
+		`tester,2024-03-25,,flags,,,"holiday: Founders, Day",,leave,weekend,weekend; holiday: Easter,`,
+		"tester,2024-03-25,3,Design,2.5,0,0,0,0,0,1,3.5",
+		"tester,2024-03-25,,total,2.5,0,0,0,0,0,1,3.5",
+	}, lines)
+}
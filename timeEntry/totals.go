@@ -0,0 +1,99 @@
+package timeEntry
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
+)
+
+// TaskTotal is the time logged against one task
+type TaskTotal struct {
+	TaskID           uint    `json:"taskid"`
+	Title            string  `json:"title"`
+	EstimatedMinutes int     `json:"estimatedMinutes"`
+	Minutes          int     `json:"minutes"`
+	Hours            float64 `json:"hours"`
+	Entries          int     `json:"entries"`
+	Running          bool    `json:"running"`
+}
+
+// TaskTotals handles reporting the time logged per task
+//
+//	@Summary		Logged time per task
+//	@Description	Total time logged per task, optionally of one user or task; running timers count up to now
+//	@Tags			Time Tracking
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string		true	"API Key"
+//
+//	@Param			username	query		string		false	"Only count entries of this user"
+//	@Param			taskid		query		int			false	"Only count entries against this task"
+//	@Success		200			{array}		TaskTotal	"Logged time retrieved successfully"
+//	@Router			/api/v2/timeEntry/totals [get]
+func TaskTotals() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var entries []models.TimeEntry
+		filteredEntries(c).Find(&entries)
+		totals := taskTotals(entries, time.Now())
+		for i := range totals {
+			var findTask models.Task
+			database.DB.Where("id=?", totals[i].TaskID).First(&findTask)
+			totals[i].Title = findTask.Title
+			totals[i].EstimatedMinutes = taskAssignment.EstimateMinutes(findTask)
+		}
+		return c.Status(fiber.StatusOK).JSON(totals)
+	}
+}
+
+// taskTotals sums the entries per task, ordered by task ID
+func taskTotals(entries []models.TimeEntry, now time.Time) []TaskTotal {
+	logged := make(map[uint]time.Duration)
+	byTask := make(map[uint]*TaskTotal)
+	for _, entry := range entries {
+		total, ok := byTask[entry.TaskID]
+		if !ok {
+			total = &TaskTotal{TaskID: entry.TaskID}
+			byTask[entry.TaskID] = total
+		}
+		total.Entries++
+		total.Running = total.Running || entry.EndTime == nil
+		logged[entry.TaskID] += entryDuration(entry, now)
+	}
+	totals := make([]TaskTotal, 0, len(byTask))
+	for taskID, total := range byTask {
+		total.Minutes = durationMinutes(logged[taskID])
+		total.Hours = minutesToHours(total.Minutes)
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].TaskID < totals[j].TaskID })
+	return totals
+}
+
+// entryDuration is the time an entry covers; a running timer counts up to now
+func entryDuration(entry models.TimeEntry, now time.Time) time.Duration {
+	end := now
+	if entry.EndTime != nil {
+		end = *entry.EndTime
+	}
+	if end.Before(entry.StartTime) {
+		return 0
+	}
+	return end.Sub(entry.StartTime)
+}
+
+// durationMinutes rounds a duration to whole minutes
+func durationMinutes(d time.Duration) int {
+	return int(d.Round(time.Minute) / time.Minute)
+}
+
+// minutesToHours converts minutes to hours rounded to two decimals
+func minutesToHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
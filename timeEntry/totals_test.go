@@ -0,0 +1,31 @@
+package timeEntry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/saran-crayonte/task/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskTotals(t *testing.T) {
+	at := func(hour, minute int) *time.Time {
+		value := time.Date(2024, 3, 4, hour, minute, 0, 0, time.UTC)
+		return &value
+	}
+	entries := []models.TimeEntry{
+		{TaskID: 2, StartTime: *at(9, 0), EndTime: at(10, 30)},
+		{TaskID: 1, StartTime: *at(11, 0), EndTime: at(11, 20)},
+		{TaskID: 2, StartTime: *at(13, 0), EndTime: at(13, 45)},
+		{TaskID: 1, StartTime: *at(16, 0)},
+	}
+	totals := taskTotals(entries, *at(16, 40))
+	assert.Equal(t, []TaskTotal{
+		{TaskID: 1, Minutes: 60, Hours: 1, Entries: 2, Running: true},
+		{TaskID: 2, Minutes: 135, Hours: 2.25, Entries: 2},
+	}, totals)
+
+	assert.Equal(t, 7, durationMinutes(6*time.Minute+40*time.Second))
+	assert.Equal(t, 0.33, minutesToHours(20))
+	assert.Equal(t, time.Duration(0), entryDuration(models.TimeEntry{StartTime: *at(12, 0)}, *at(11, 0)))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/saran-crayonte/task/database"
 	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/taskAssignment"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -239,6 +240,7 @@ func DeleteUser() fiber.Handler {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid Password"})
 		}
 		deleteInTaskAssignment(b.Username)
+		taskAssignment.InvalidateLeaveCache()
 		database.DB.Delete(&existingUser)
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"message": "User deleted successfully",
@@ -248,6 +250,9 @@ func DeleteUser() fiber.Handler {
 func deleteInTaskAssignment(username string) {
 	taskAssignment := new(models.TaskAssignment)
 	database.DB.Where("username=?", username).Delete(&taskAssignment)
+	database.DB.Where("username=?", username).Delete(&models.UserSchedule{})
+	database.DB.Where("username=?", username).Delete(&models.Leave{})
+	database.DB.Where("username=?", username).Delete(&models.TimeEntry{})
 }
 
 type CustomClaims struct {
@@ -405,3 +410,142 @@ func DisplayAllUsers() fiber.Handler {
 		return c.Status(fiber.StatusOK).JSON(user)
 	}
 }
+
+// SetSchedule handles creating or replacing a user's working schedule
+//
+//	@Summary		Set user working schedule
+//	@Description	Set the working days (comma separated), hours per day, IANA timezone and holiday calendar of a user; 0 hours means the full calendar day
+//	@Tags			User Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			schedule	body		models.UserSchedule	true	"User schedule details"
+//	@Success		200			{object}	models.UserSchedule	"Schedule updated successfully"
+//	@Failure		400			{object}	string				"Invalid request payload / Invalid schedule / working days must include a working day of the working calendar"
+//	@Failure		404			{object}	string				"Username doesn't exist / Holiday calendar not found"
+//	@Failure		500			{object}	string				"Failed to reschedule assignments"
+//	@Router			/api/v2/user/schedule [put]
+func SetSchedule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		schedule := new(models.UserSchedule)
+		if err := json.Unmarshal(c.Body(), &schedule); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if schedule.Username == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+
+		var existingUser models.User
+		database.DB.Where("username=?", schedule.Username).First(&existingUser)
+		if len(existingUser.Username) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Username doesn't exists"})
+		}
+
+		if _, err := taskAssignment.ActiveWorkingCalendar().ForSchedule(*schedule); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if _, err := taskAssignment.LoadLocation(schedule.Timezone); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if schedule.CalendarID != 0 {
+			var calendar models.HolidayCalendar
+			database.DB.Where("id=?", schedule.CalendarID).First(&calendar)
+			if calendar.ID == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Holiday calendar not found"})
+			}
+		}
+
+		database.DB.Save(&schedule)
+		if err := updateScheduleInAssignment(schedule.Username); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(schedule)
+	}
+}
+
+// GetSchedule handles retrieving a user's working schedule
+//
+//	@Summary		Get user working schedule
+//	@Description	Retrieve the working schedule of a user
+//	@Tags			User Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			schedule	body		models.UserSchedule	true	"Username"
+//	@Success		200			{object}	models.UserSchedule	"Schedule retrieved successfully"
+//	@Failure		400			{object}	string				"Invalid request payload"
+//	@Failure		404			{object}	string				"Schedule not found"
+//	@Router			/api/v2/user/schedule [get]
+func GetSchedule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			Username string
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var schedule models.UserSchedule
+		database.DB.Where("username=?", b.Username).First(&schedule)
+		if len(schedule.Username) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Schedule not found"})
+		}
+		return c.Status(fiber.StatusOK).JSON(schedule)
+	}
+}
+
+// DeleteSchedule handles removing a user's working schedule
+//
+//	@Summary		Delete user working schedule
+//	@Description	Remove the working schedule of a user so the full calendar applies again
+//	@Tags			User Management
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string				true	"API Key"
+//
+//	@Param			schedule	body		models.UserSchedule	true	"Username"
+//	@Success		200			{object}	string				"Schedule deleted successfully"
+//	@Failure		400			{object}	string				"Invalid request payload"
+//	@Failure		404			{object}	string				"Schedule not found"
+//	@Failure		500			{object}	string				"Failed to reschedule assignments"
+//	@Router			/api/v2/user/schedule [delete]
+func DeleteSchedule() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			Username string
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var schedule models.UserSchedule
+		database.DB.Where("username=?", b.Username).First(&schedule)
+		if len(schedule.Username) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Schedule not found"})
+		}
+		database.DB.Delete(&schedule)
+		if err := updateScheduleInAssignment(b.Username); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reschedule assignments"})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Schedule deleted successfully",
+		})
+	}
+}
+
+// updateScheduleInAssignment recomputes the user's assignments after their
+// schedule changed; shares of other users keep their dates
+func updateScheduleInAssignment(username string) error {
+	var taskAssignments []models.TaskAssignment
+	database.DB.Where("username=?", username).Find(&taskAssignments)
+	_, err := taskAssignment.RecomputeAssignments(taskAssignments)
+	return err
+}
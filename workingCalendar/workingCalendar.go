@@ -0,0 +1,205 @@
+package workingCalendar
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/saran-crayonte/task/database"
+	"github.com/saran-crayonte/task/models"
+	"github.com/saran-crayonte/task/task"
+	"github.com/saran-crayonte/task/taskAssignment"
+)
+
+// CreateWorkingCalendar handles creating a new working calendar
+//
+//	@Summary		Create a new working calendar
+//	@Description	Create a working calendar with daily hours (HH:MM), breaks (HH:MM-HH:MM, comma separated) and weekend days (comma separated)
+//	@Tags			Working Calendar
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string					true	"API Key"
+//
+//	@Param			calendar	body		models.WorkingCalendar	true	"Working calendar details"
+//	@Success		201			{object}	models.WorkingCalendar	"Working calendar created successfully"
+//	@Failure		400			{object}	string					"Invalid request payload / Invalid calendar"
+//	@Router			/api/v2/workingCalendar [post]
+func CreateWorkingCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		calendar := new(models.WorkingCalendar)
+		if err := json.Unmarshal(c.Body(), &calendar); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if calendar.Name == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		if _, err := taskAssignment.ParseWorkingCalendar(*calendar); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		calendar.ID = 0
+		if calendar.IsDefault {
+			clearDefault()
+		}
+		database.DB.Create(&calendar)
+		if calendar.IsDefault {
+			updateCalendarInAssignment()
+		}
+		return c.Status(fiber.StatusCreated).JSON(calendar)
+	}
+}
+
+// GetWorkingCalendar handles retrieving a working calendar by ID
+//
+//	@Summary		Get a working calendar by ID
+//	@Description	Retrieve a working calendar by its ID
+//	@Tags			Working Calendar
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string					true	"API Key"
+//
+//	@Param			id		path		int						true	"Working Calendar ID"
+//	@Success		200		{object}	models.WorkingCalendar	"Working calendar retrieved successfully"
+//	@Failure		400		{object}	string					"Invalid request payload"
+//	@Failure		404		{object}	string					"Working calendar not found"
+//	@Router			/api/v2/workingCalendar/{id} [get]
+func GetWorkingCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var calendar models.WorkingCalendar
+		database.DB.Where("id=?", b.ID).First(&calendar)
+		if calendar.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Working calendar not found"})
+		}
+		return c.Status(fiber.StatusOK).JSON(calendar)
+	}
+}
+
+// UpdateWorkingCalendar handles updating a working calendar by ID
+//
+//	@Summary		Update a working calendar by ID
+//	@Description	Replace an existing working calendar with the given details; assignment end dates are recomputed when the default calendar changes
+//	@Tags			Working Calendar
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token		header		string					true	"API Key"
+//
+//	@Param			calendar	body		models.WorkingCalendar	true	"Updated working calendar details"
+//	@Success		200			{object}	string					"Working calendar updated successfully"
+//	@Failure		400			{object}	string					"Invalid request payload / Invalid calendar"
+//	@Failure		404			{object}	string					"Working calendar not found"
+//	@Router			/api/v2/workingCalendar/{id} [put]
+func UpdateWorkingCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		calendar := new(models.WorkingCalendar)
+		if err := json.Unmarshal(c.Body(), &calendar); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var existingCalendar models.WorkingCalendar
+		database.DB.Where("id=?", calendar.ID).First(&existingCalendar)
+		if existingCalendar.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Working calendar not found"})
+		}
+		if _, err := taskAssignment.ParseWorkingCalendar(*calendar); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		wasDefault := existingCalendar.IsDefault
+		if calendar.IsDefault {
+			clearDefault()
+		}
+		// the calendar is replaced as validated, so cleared breaks and weekend
+		// days are written too
+		database.DB.Model(&existingCalendar).Select("*").Updates(calendar)
+		if wasDefault || calendar.IsDefault {
+			updateCalendarInAssignment()
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Working calendar updated successfully"})
+	}
+}
+
+// DeleteWorkingCalendar handles deleting a working calendar by ID
+//
+//	@Summary		Delete a working calendar by ID
+//	@Description	Delete an existing working calendar by its ID
+//	@Tags			Working Calendar
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string	true	"API Key"
+//
+//	@Param			id		path		int		true	"Working Calendar ID"
+//	@Success		200		{object}	string	"Working calendar deleted successfully"
+//	@Failure		400		{object}	string	"Invalid request payload"
+//	@Failure		404		{object}	string	"Working calendar not found"
+//	@Router			/api/v2/workingCalendar/{id} [delete]
+func DeleteWorkingCalendar() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		type body struct {
+			ID int
+		}
+		b := new(body)
+		if err := json.Unmarshal(c.Body(), &b); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+		var calendar models.WorkingCalendar
+		database.DB.Where("id=?", b.ID).First(&calendar)
+		if calendar.ID == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Working calendar not found"})
+		}
+		database.DB.Delete(&calendar)
+		if calendar.IsDefault {
+			updateCalendarInAssignment()
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Working calendar deleted successfully",
+		})
+	}
+}
+
+// DisplayAllWorkingCalendars handles retrieving all working calendars
+//
+//	@Summary		Get all working calendars
+//	@Description	Retrieve all working calendars
+//	@Tags			Working Calendar
+//	@Accept			json
+//	@Produce		json
+//
+//	@Security		ApiKeyAuth
+//	@Param			token	header		string					true	"API Key"
+//
+//	@Success		200		{object}	models.WorkingCalendar	"Working calendars retrieved successfully"
+//	@Router			/api/v2/workingCalendar [get]
+func DisplayAllWorkingCalendars() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var calendars []models.WorkingCalendar
+		database.DB.Find(&calendars)
+		return c.Status(fiber.StatusOK).JSON(calendars)
+	}
+}
+
+func clearDefault() {
+	database.DB.Model(&models.WorkingCalendar{}).Where("is_default = ?", true).Update("is_default", false)
+}
+
+func updateCalendarInAssignment() {
+	var taskAssignments []models.TaskAssignment
+	database.DB.Find(&taskAssignments)
+	for _, assignment := range taskAssignments {
+		var findTask models.Task
+		database.DB.Where("id=?", assignment.TaskID).First(&findTask)
+		if findTask.ID != 0 {
+			task.UpdatesInTaskAssignment(findTask.ID, taskAssignment.EstimateMinutes(findTask))
+		}
+	}
+}